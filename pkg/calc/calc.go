@@ -29,6 +29,10 @@ func Factorial(ctx context.Context, n *big.Float, step int) (*big.Float, error)
 		return one, nil
 	}
 
+	if n.IsInf() {
+		return big.NewFloat(0).SetInf(false), nil
+	}
+
 	stepInt := big.NewInt(int64(step))
 	if intN, accuracy := n.Int(nil); accuracy == big.Exact {
 		if err := ctx.Err(); err != nil {
@@ -46,55 +50,63 @@ func Factorial(ctx context.Context, n *big.Float, step int) (*big.Float, error)
 	return nil, fmt.Errorf("factorial is only defined for integers")
 }
 
-// GreatestCommonDivisor calculates the greatest common divisor of two numbers x and y using the Euclidean algorithm
-func GreatestCommonDivisor(ctx context.Context, x, y *big.Float) (*big.Float, error) {
+// GreatestCommonDivisor calculates the greatest common divisor of the numbers in args using the Euclidean algorithm,
+// i.e., GCD(x, y, z) = GCD(GCD(x, y), z)
+func GreatestCommonDivisor(ctx context.Context, args ...*big.Float) (*big.Float, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
-	xInt, accuracy := x.Int(nil)
-	if accuracy != big.Exact {
-		return nil, fmt.Errorf("GCD function requires integer arguments")
+	ints, err := integers("GCD", args...)
+	if err != nil {
+		return nil, err
 	}
 
-	yInt, accuracy := y.Int(nil)
-	if accuracy != big.Exact {
-		return nil, fmt.Errorf("GCD function requires integer arguments")
+	gcd := new(big.Int).Abs(ints[0])
+	for _, x := range ints[1:] {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		gcd.GCD(nil, nil, gcd, new(big.Int).Abs(x))
 	}
 
-	return big.NewFloat(0).SetInt(new(big.Int).GCD(nil, nil, xInt, yInt)), nil
+	return new(big.Float).SetInt(gcd), nil
 }
 
-// LeastCommonMultiple calculates the least common multiple of two numbers x and y using the formula LCM(x, y) = x * y / GCD(x, y)
-func LeastCommonMultiple(ctx context.Context, x, y *big.Float) (*big.Float, error) {
+// LeastCommonMultiple calculates the least common multiple of the numbers in args using the formula LCM(x, y) = x * y / GCD(x, y),
+// i.e., LCM(x, y, z) = LCM(LCM(x, y), z)
+func LeastCommonMultiple(ctx context.Context, args ...*big.Float) (*big.Float, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
-	xInt, accuracy := x.Int(nil)
-	if accuracy != big.Exact {
-		return nil, fmt.Errorf("LCM function requires integer arguments")
+	ints, err := integers("LCM", args...)
+	if err != nil {
+		return nil, err
 	}
 
-	yInt, accuracy := y.Int(nil)
-	if accuracy != big.Exact {
-		return nil, fmt.Errorf("LCM function requires integer arguments")
-	}
+	lcm := new(big.Int).Abs(ints[0])
+	for _, x := range ints[1:] {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 
-	gcd := new(big.Int).GCD(nil, nil, xInt, yInt)
-	if gcd.Cmp(big.NewInt(0)) == 0 {
-		return big.NewFloat(0), nil
-	}
+		gcd := new(big.Int).GCD(nil, nil, lcm, new(big.Int).Abs(x))
+		if gcd.Sign() == 0 {
+			continue // LCM(0, 0) = 0
+		}
 
-	// Calculate LCM(x, y) = abs(x * y) / GCD(x, y)
-	lcm := big.NewInt(0).Mul(xInt, yInt)
-	lcm = lcm.Quo(lcm, gcd)
-	lcm = lcm.Abs(lcm) // Ensure LCM is positive
+		// Calculate LCM(x, y) = abs(x * y) / GCD(x, y)
+		lcm.Mul(lcm, x)
+		lcm.Quo(lcm, gcd)
+		lcm.Abs(lcm) // Ensure LCM is positive
+	}
 
-	return big.NewFloat(0).SetInt(lcm), nil
+	return new(big.Float).SetInt(lcm), nil
 }
 
-// Pow calculates base^exp for big.Float values using exp(ln(base) * exp)
+// Pow calculates base^exp for big.Float values with integer exponents by repeated squaring
 func Pow(ctx context.Context, base, exponent *big.Float) (*big.Float, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
@@ -104,10 +116,31 @@ func Pow(ctx context.Context, base, exponent *big.Float) (*big.Float, error) {
 		return nil, fmt.Errorf("0^0 is undefined")
 	}
 
+	if base.Sign() == 0 && exponent.Sign() < 0 {
+		return nil, fmt.Errorf("division by zero")
+	}
+
 	// Handle simple cases
 	zero := big.NewFloat(0)
 	one := big.NewFloat(1)
 
+	// Handle infinite exponents by their limits, which do not exist for |base| = 1,
+	// or if the magnitude of a negative base grows infinitely
+	if exponent.IsInf() {
+		magnitude := big.NewFloat(0).Abs(base).Cmp(one)
+		infinite := (magnitude > 0) == (exponent.Sign() > 0)
+		switch {
+		case magnitude == 0, infinite && base.Sign() < 0:
+			return nil, fmt.Errorf("%s^%s is undefined", base.Text('g', 10), exponent.Text('g', 10))
+
+		case infinite:
+			return big.NewFloat(0).SetInf(false), nil
+
+		}
+
+		return zero, nil
+	}
+
 	if exponent.Cmp(zero) == 0 {
 		return one, nil
 	}
@@ -116,16 +149,38 @@ func Pow(ctx context.Context, base, exponent *big.Float) (*big.Float, error) {
 		return base, nil
 	}
 
-	// Handle integer exponents directly
-	oneInt := big.NewInt(1)
+	// Handle integer exponents by repeated squaring, i.e., base^13 = base^8 * base^4 * base^1.
+	// The squares overflow to infinity or underflow to zero after at most about the precision of the base plus 32 squarings,
+	// which ends the squaring,
+	// hence the number of multiplications is bounded independently of the exponent.
 	if intExp, accuracy := exponent.Int(nil); accuracy == big.Exact {
-		if err := ctx.Err(); err != nil {
-			return nil, err
+		if big.NewFloat(0).Abs(base).Cmp(one) == 0 { // Powers of -1 alternate between 1 and -1
+			if base.Sign() < 0 && intExp.Bit(0) == 1 {
+				return big.NewFloat(-1), nil
+			}
+			return one, nil
 		}
 
 		result := big.NewFloat(1)
-		for i := big.NewInt(0); i.Cmp(big.NewInt(0).Abs(intExp)) < 0; i.Add(i, oneInt) {
-			result = result.Mul(result, base)
+		square := big.NewFloat(0).Set(base) // base^(2^i)
+		n := big.NewInt(0).Abs(intExp)
+		for i := 0; i < n.BitLen(); i++ {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			if i > 0 {
+				square.Mul(square, square)
+			}
+
+			if i > 0 && (square.IsInf() || square.Sign() == 0) { // the remaining bits include the leading one
+				result.Mul(result, square)
+				break
+			}
+
+			if n.Bit(i) == 1 {
+				result.Mul(result, square)
+			}
 		}
 
 		if intExp.Sign() < 0 { // Negative exponent: take reciprocal
@@ -137,3 +192,37 @@ func Pow(ctx context.Context, base, exponent *big.Float) (*big.Float, error) {
 
 	return nil, fmt.Errorf("non-integer exponents are not supported")
 }
+
+// integer converts x into a big.Int.
+// It returns an error mentioning the function name if x is not an integer.
+func integer(name string, x *big.Float) (*big.Int, error) {
+	if x == nil || x.IsInf() {
+		return nil, fmt.Errorf("%s function requires integer arguments", name)
+	}
+
+	xInt, accuracy := x.Int(nil)
+	if accuracy != big.Exact {
+		return nil, fmt.Errorf("%s function requires integer arguments", name)
+	}
+
+	return xInt, nil
+}
+
+// integers converts all args into big.Int values.
+// It returns an error mentioning the function name if there are no args or any of them is not an integer.
+func integers(name string, args ...*big.Float) ([]*big.Int, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%s function requires at least 1 argument", name)
+	}
+
+	ints := make([]*big.Int, len(args))
+	for i, arg := range args {
+		xInt, err := integer(name, arg)
+		if err != nil {
+			return nil, err
+		}
+		ints[i] = xInt
+	}
+
+	return ints, nil
+}
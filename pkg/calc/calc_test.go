@@ -2,6 +2,7 @@ package calc
 
 import (
 	"context"
+	"math"
 	"math/big"
 	"testing"
 )
@@ -122,6 +123,18 @@ func TestPow(t *testing.T) {
 		{"test#1", args{3, 2}, 9},
 		{"test#2", args{2.5, 2}, 6.25},
 		{"test#3", args{5, -2}, 1.0 / 25},
+		{"test#4", args{2, math.Inf(1)}, math.Inf(1)},
+		{"test#5", args{-0.5, math.Inf(1)}, 0},
+		{"test#6", args{2, math.Inf(-1)}, 0},
+		{"test#7", args{0.5, math.Inf(-1)}, math.Inf(1)},
+		{"test#8", args{math.Inf(-1), 3}, math.Inf(-1)},
+		{"test#9", args{math.Inf(1), -1}, 0},
+		{"test#10", args{3, 33}, 5559060566555523},
+		{"test#11", args{-1, 1e9}, 1},
+		{"test#12", args{-1, 1e9 + 1}, -1},
+		{"test#13", args{1.5, 1e18}, math.Inf(1)},
+		{"test#14", args{-0.5, -1e15 - 1}, math.Inf(-1)},
+		{"test#15", args{-2, 1e15}, math.Inf(1)},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			if got, err := Pow(context.TODO(), big.NewFloat(tt.args.x), big.NewFloat(tt.args.y)); err != nil {
@@ -132,3 +145,65 @@ func TestPow(t *testing.T) {
 		})
 	}
 }
+
+func TestPowUndefined(t *testing.T) {
+	type args struct {
+		x, y float64
+	}
+
+	for _, tt := range []struct {
+		name string
+		args args
+	}{
+		{"test#1", args{0, 0}},
+		{"test#2", args{0, -1}},
+		{"test#3", args{1, math.Inf(1)}},
+		{"test#4", args{-2, math.Inf(1)}},
+		{"test#5", args{-0.5, math.Inf(-1)}},
+		{"test#6", args{2, 0.5}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := Pow(context.TODO(), big.NewFloat(tt.args.x), big.NewFloat(tt.args.y)); err == nil {
+				t.Errorf("Pow(%f, %f) = %v, want error", tt.args.x, tt.args.y, got)
+			}
+		})
+	}
+}
+
+func TestGreatestCommonDivisorAndLeastCommonMultiple(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		args    []float64
+		wantGCD float64
+		wantLCM float64
+	}{
+		{"test#1", []float64{12}, 12, 12},
+		{"test#2", []float64{-12}, 12, 12},
+		{"test#3", []float64{12, 18, 30}, 6, 180},
+		{"test#4", []float64{4, 6, 10, 15}, 1, 60},
+		{"test#5", []float64{0, 6, -9}, 3, 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var args []*big.Float
+			for _, arg := range tt.args {
+				args = append(args, big.NewFloat(arg))
+			}
+
+			if got, err := GreatestCommonDivisor(context.TODO(), args...); err != nil {
+				t.Errorf("Error calculating gcd%v: %v", tt.args, err)
+			} else if got.Cmp(big.NewFloat(tt.wantGCD)) != 0 {
+				t.Errorf("GreatestCommonDivisor%v = %v, want %g", tt.args, got, tt.wantGCD)
+			}
+
+			if got, err := LeastCommonMultiple(context.TODO(), args...); err != nil {
+				t.Errorf("Error calculating lcm%v: %v", tt.args, err)
+			} else if got.Cmp(big.NewFloat(tt.wantLCM)) != 0 {
+				t.Errorf("LeastCommonMultiple%v = %v, want %g", tt.args, got, tt.wantLCM)
+			}
+		})
+	}
+
+	if _, err := GreatestCommonDivisor(context.TODO()); err == nil {
+		t.Errorf("GreatestCommonDivisor() without arguments succeeded, want error")
+	}
+}
@@ -0,0 +1,546 @@
+package calc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// BinomialCDF calculates the probability P(X <= k) of a binomially distributed random variable X
+// with n trials and success probability p.
+func BinomialCDF(ctx context.Context, n, p, k *big.Float) (*big.Float, error) {
+	prec := precision(n, p, k) + guardBits
+	nInt, kInt, err := binomialParameters("binomcdf", n, p, k)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case kInt.Sign() < 0:
+		return big.NewFloat(0), nil
+
+	case kInt.Cmp(nInt) >= 0, p.Sign() == 0:
+		return big.NewFloat(1), nil
+
+	case p.Cmp(big.NewFloat(1)) == 0:
+		return big.NewFloat(0), nil
+
+	}
+
+	// term(0) = (1-p)^n, term(i+1) = term(i) * (n-i)/(i+1) * p/(1-p)
+	q := new(big.Float).SetPrec(prec).Sub(big.NewFloat(1), p)
+	ratio := new(big.Float).SetPrec(prec).Quo(p, q)
+
+	term, err := power(ctx, q, nInt, prec)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(big.Float).SetPrec(prec).Set(term)
+	for i := big.NewInt(0); i.Cmp(kInt) < 0; i.Add(i, big.NewInt(1)) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		term.Mul(term, new(big.Float).SetInt(new(big.Int).Sub(nInt, i)))
+		term.Quo(term, new(big.Float).SetInt(new(big.Int).Add(i, big.NewInt(1))))
+		term.Mul(term, ratio)
+		result.Add(result, term)
+	}
+
+	return result.SetPrec(prec - guardBits), nil
+}
+
+// BinomialPDF calculates the probability P(X = k) of a binomially distributed random variable X
+// with n trials and success probability p, i.e., nCk * p^k * (1-p)^(n-k)
+func BinomialPDF(ctx context.Context, n, p, k *big.Float) (*big.Float, error) {
+	prec := precision(n, p, k) + guardBits
+	nInt, kInt, err := binomialParameters("binompdf", n, p, k)
+	if err != nil {
+		return nil, err
+	}
+
+	if kInt.Sign() < 0 || kInt.Cmp(nInt) > 0 {
+		return big.NewFloat(0), nil
+	}
+
+	coefficient, err := Binomial(ctx, n, k)
+	if err != nil {
+		return nil, err
+	}
+
+	success, err := power(ctx, p, kInt, prec)
+	if err != nil {
+		return nil, err
+	}
+
+	failure, err := power(ctx, new(big.Float).SetPrec(prec).Sub(big.NewFloat(1), p), new(big.Int).Sub(nInt, kInt), prec)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(big.Float).SetPrec(prec).Mul(coefficient, success)
+
+	return result.Mul(result, failure).SetPrec(prec - guardBits), nil
+}
+
+// ChiSquareCDF calculates the probability P(lower <= X <= upper) of a chi-squared distributed random variable X
+// with df degrees of freedom. The degrees of freedom must be a positive integer.
+func ChiSquareCDF(ctx context.Context, lower, upper, df *big.Float) (*big.Float, error) {
+	prec := precision(lower, upper, df) + guardBits
+	dfInt, err := degreesOfFreedom("chi2cdf", df)
+	if err != nil {
+		return nil, err
+	}
+
+	if lower.Cmp(upper) > 0 {
+		return nil, fmt.Errorf("%w: lower bound must not be greater than the upper bound", ErrDomain)
+	}
+
+	// P(lower <= X <= upper) = Q(lower) - Q(upper), where Q is the survival function
+	lowerTail, err := chiSquareSurvival(ctx, lower, dfInt, prec)
+	if err != nil {
+		return nil, err
+	}
+
+	upperTail, err := chiSquareSurvival(ctx, upper, dfInt, prec)
+	if err != nil {
+		return nil, err
+	}
+
+	return lowerTail.Sub(lowerTail, upperTail).SetPrec(prec - guardBits), nil
+}
+
+// InverseNormal calculates the quantile x of a normally distributed random variable X with the given mean and
+// standard deviation, such that P(X <= x) = p. The quantile is refined using Newton's method at the working precision.
+func InverseNormal(ctx context.Context, p, mean, sd *big.Float) (*big.Float, error) {
+	prec := precision(p, mean, sd) + guardBits
+	if p.Sign() <= 0 || p.Cmp(big.NewFloat(1)) >= 0 {
+		return nil, fmt.Errorf("%w: probability must be between 0 and 1 exclusively", ErrDomain)
+	}
+
+	if sd.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: standard deviation must be positive", ErrDomain)
+	}
+
+	// initial guess using float64 arithmetic, clamped to the range representable by float64
+	guess, _ := p.Float64()
+	z := new(big.Float).SetPrec(prec).SetFloat64(math.Max(-38, math.Min(38, -math.Sqrt2*math.Erfcinv(2*guess))))
+
+	for i := 0; i < int(prec); i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// z = z - (Φ(z) - p) / φ(z)
+		cdf, err := standardNormalCDF(ctx, z, prec)
+		if err != nil {
+			return nil, err
+		}
+
+		pdf, err := standardNormalPDF(ctx, z, prec)
+		if err != nil {
+			return nil, err
+		}
+
+		if pdf.Sign() == 0 {
+			break
+		}
+
+		step := cdf.Sub(cdf, p)
+		step.Quo(step, pdf)
+		z.Sub(z, step)
+
+		scale := new(big.Float).SetPrec(prec).Abs(z)
+		if converged(scale.Add(scale, big.NewFloat(1)), step, prec-guardBits) {
+			break
+		}
+	}
+
+	// x = mean + sd * z
+	result := z.Mul(z, sd)
+
+	return result.Add(result, mean).SetPrec(prec - guardBits), nil
+}
+
+// NormalCDF calculates the probability P(lower <= X <= upper) of a normally distributed random variable X
+// with the given mean and standard deviation.
+func NormalCDF(ctx context.Context, lower, upper, mean, sd *big.Float) (*big.Float, error) {
+	prec := precision(lower, upper, mean, sd) + guardBits
+	if sd.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: standard deviation must be positive", ErrDomain)
+	}
+
+	if lower.Cmp(upper) > 0 {
+		return nil, fmt.Errorf("%w: lower bound must not be greater than the upper bound", ErrDomain)
+	}
+
+	lowerZ := standardize(lower, mean, sd, prec)
+	upperZ := standardize(upper, mean, sd, prec)
+
+	// use the tail, in which the interval lies, to retain the precision of small probabilities
+	var lowerTail, upperTail *big.Float
+	var err error
+	if lowerZ.Sign() >= 0 { // P = Q(lower) - Q(upper)
+		if lowerTail, err = standardNormalCDF(ctx, lowerZ.Neg(lowerZ), prec); err != nil {
+			return nil, err
+		}
+		if upperTail, err = standardNormalCDF(ctx, upperZ.Neg(upperZ), prec); err != nil {
+			return nil, err
+		}
+		return lowerTail.Sub(lowerTail, upperTail).SetPrec(prec - guardBits), nil
+	}
+
+	// P = Φ(upper) - Φ(lower)
+	if upperTail, err = standardNormalCDF(ctx, upperZ, prec); err != nil {
+		return nil, err
+	}
+	if lowerTail, err = standardNormalCDF(ctx, lowerZ, prec); err != nil {
+		return nil, err
+	}
+
+	return upperTail.Sub(upperTail, lowerTail).SetPrec(prec - guardBits), nil
+}
+
+// NormalPDF calculates the probability density of a normally distributed random variable
+// with the given mean and standard deviation at x, i.e., exp(-((x - mean) / sd)^2 / 2) / (sd * sqrt(2π))
+func NormalPDF(ctx context.Context, x, mean, sd *big.Float) (*big.Float, error) {
+	prec := precision(x, mean, sd) + guardBits
+	if sd.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: standard deviation must be positive", ErrDomain)
+	}
+
+	result, err := standardNormalPDF(ctx, standardize(x, mean, sd, prec), prec)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Quo(result, sd).SetPrec(prec - guardBits), nil
+}
+
+// PoissonCDF calculates the probability P(X <= k) of a Poisson distributed random variable X
+// with the mean lambda, i.e., exp(-lambda) * Σ lambda^i / i! for i = 0, ..., k
+func PoissonCDF(ctx context.Context, lambda, k *big.Float) (*big.Float, error) {
+	prec := precision(lambda, k) + guardBits
+	kInt, err := poissonParameters("poissoncdf", lambda, k)
+	if err != nil {
+		return nil, err
+	}
+
+	if kInt.Sign() < 0 {
+		return big.NewFloat(0), nil
+	}
+
+	term, err := exp(ctx, new(big.Float).SetPrec(prec).Neg(lambda), prec)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(big.Float).SetPrec(prec).Set(term)
+	for i := big.NewInt(1); i.Cmp(kInt) <= 0; i.Add(i, big.NewInt(1)) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		term.Mul(term, lambda).Quo(term, new(big.Float).SetInt(i))
+		result.Add(result, term)
+	}
+
+	return result.SetPrec(prec - guardBits), nil
+}
+
+// PoissonPDF calculates the probability P(X = k) of a Poisson distributed random variable X
+// with the mean lambda, i.e., exp(-lambda) * lambda^k / k!
+func PoissonPDF(ctx context.Context, lambda, k *big.Float) (*big.Float, error) {
+	prec := precision(lambda, k) + guardBits
+	kInt, err := poissonParameters("poissonpdf", lambda, k)
+	if err != nil {
+		return nil, err
+	}
+
+	if kInt.Sign() < 0 {
+		return big.NewFloat(0), nil
+	}
+
+	result, err := exp(ctx, new(big.Float).SetPrec(prec).Neg(lambda), prec)
+	if err != nil {
+		return nil, err
+	}
+
+	numerator, err := power(ctx, lambda, kInt, prec)
+	if err != nil {
+		return nil, err
+	}
+
+	if !kInt.IsInt64() {
+		return nil, fmt.Errorf("%w: number of events is too large", ErrDomain)
+	}
+
+	denominator := new(big.Float).SetInt(new(big.Int).MulRange(1, kInt.Int64()))
+	result.Mul(result, numerator).Quo(result, denominator)
+
+	return result.SetPrec(prec - guardBits), nil
+}
+
+// StudentTCDF calculates the probability P(lower <= T <= upper) of a Student's t-distributed random variable T
+// with df degrees of freedom. The degrees of freedom must be a positive integer.
+func StudentTCDF(ctx context.Context, lower, upper, df *big.Float) (*big.Float, error) {
+	prec := precision(lower, upper, df) + guardBits
+	dfInt, err := degreesOfFreedom("tcdf", df)
+	if err != nil {
+		return nil, err
+	}
+
+	if lower.Cmp(upper) > 0 {
+		return nil, fmt.Errorf("%w: lower bound must not be greater than the upper bound", ErrDomain)
+	}
+
+	lowerCDF, err := studentTCDF(ctx, lower, dfInt, prec)
+	if err != nil {
+		return nil, err
+	}
+
+	upperCDF, err := studentTCDF(ctx, upper, dfInt, prec)
+	if err != nil {
+		return nil, err
+	}
+
+	return upperCDF.Sub(upperCDF, lowerCDF).SetPrec(prec - guardBits), nil
+}
+
+// binomialParameters validates the parameters of the binomial distribution.
+func binomialParameters(name string, n, p, k *big.Float) (*big.Int, *big.Int, error) {
+	ints, err := integers(name, n, k)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if ints[0].Sign() < 0 {
+		return nil, nil, fmt.Errorf("%w: number of trials must be a non-negative integer", ErrDomain)
+	}
+
+	if p.Sign() < 0 || p.Cmp(big.NewFloat(1)) > 0 {
+		return nil, nil, fmt.Errorf("%w: probability must be between 0 and 1", ErrDomain)
+	}
+
+	return ints[0], ints[1], nil
+}
+
+// chiSquareSurvival calculates the survival function Q(x) = P(X > x) of the chi-squared distribution
+// with df degrees of freedom using the closed forms of the regularized upper incomplete gamma function Q(df/2, x/2):
+// for even df = 2m: Q = exp(-y) * Σ y^k / k! for k = 0, ..., m-1
+// for odd df = 2m+1: Q = erfc(sqrt(y)) + exp(-y) * Σ y^(k-1/2) / Γ(k+1/2) for k = 1, ..., m
+func chiSquareSurvival(ctx context.Context, x *big.Float, df *big.Int, prec uint) (*big.Float, error) {
+	switch {
+	case x.Sign() <= 0:
+		return big.NewFloat(1), nil
+
+	case x.IsInf():
+		return big.NewFloat(0), nil
+
+	}
+
+	y := new(big.Float).SetPrec(prec).Quo(x, big.NewFloat(2))
+	m := new(big.Int).Rsh(df, 1)
+
+	weight, err := exp(ctx, new(big.Float).SetPrec(prec).Neg(y), prec)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(big.Float).SetPrec(prec)
+	term := new(big.Float).SetPrec(prec)
+	if df.Bit(0) == 0 { // even: term(0) = 1, term(k) = term(k-1) * y / k
+		term.SetInt64(1)
+		for k := big.NewInt(0); k.Cmp(m) < 0; {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			result.Add(result, term)
+			k.Add(k, big.NewInt(1))
+			term.Mul(term, y).Quo(term, new(big.Float).SetInt(k))
+		}
+
+		return result.Mul(result, weight), nil
+	}
+
+	// odd: term(1) = 2 * sqrt(y / π), term(k+1) = term(k) * y / (k + 1/2)
+	piValue, err := pi(ctx, prec)
+	if err != nil {
+		return nil, err
+	}
+
+	term.Quo(y, piValue).Sqrt(term).Mul(term, big.NewFloat(2))
+	for k := big.NewInt(1); k.Cmp(m) <= 0; k.Add(k, big.NewInt(1)) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		result.Add(result, term)
+		term.Mul(term, y).Quo(term, new(big.Float).SetPrec(prec).Add(new(big.Float).SetInt(k), big.NewFloat(0.5)))
+	}
+	result.Mul(result, weight)
+
+	tail, err := erfc(ctx, new(big.Float).SetPrec(prec).Sqrt(y), prec)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Add(result, tail), nil
+}
+
+// degreesOfFreedom validates the degrees of freedom of a distribution.
+func degreesOfFreedom(name string, df *big.Float) (*big.Int, error) {
+	dfInt, err := integer(name, df)
+	if err != nil || dfInt.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: degrees of freedom must be a positive integer", ErrDomain)
+	}
+
+	return dfInt, nil
+}
+
+// poissonParameters validates the parameters of the Poisson distribution.
+func poissonParameters(name string, lambda, k *big.Float) (*big.Int, error) {
+	kInt, err := integer(name, k)
+	if err != nil {
+		return nil, err
+	}
+
+	if lambda.Sign() <= 0 || lambda.IsInf() {
+		return nil, fmt.Errorf("%w: mean must be positive", ErrDomain)
+	}
+
+	return kInt, nil
+}
+
+// power calculates x^n for a non-negative integer n using binary exponentiation with the given precision.
+func power(ctx context.Context, x *big.Float, n *big.Int, prec uint) (*big.Float, error) {
+	result := new(big.Float).SetPrec(prec).SetInt64(1)
+	base := new(big.Float).SetPrec(prec).Set(x)
+
+	for i := 0; i < n.BitLen(); i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if n.Bit(i) == 1 {
+			result.Mul(result, base)
+		}
+		base.Mul(base, base)
+	}
+
+	return result, nil
+}
+
+// standardize calculates z = (x - mean) / sd with the given precision.
+func standardize(x, mean, sd *big.Float, prec uint) *big.Float {
+	z := new(big.Float).SetPrec(prec).Sub(x, mean)
+	return z.Quo(z, sd)
+}
+
+// standardNormalCDF calculates the cumulative distribution function of the standard normal distribution,
+// i.e., Φ(z) = erfc(-z / sqrt(2)) / 2
+func standardNormalCDF(ctx context.Context, z *big.Float, prec uint) (*big.Float, error) {
+	arg := new(big.Float).SetPrec(prec).Quo(z, new(big.Float).SetPrec(prec).Sqrt(big.NewFloat(2)))
+	result, err := erfc(ctx, arg.Neg(arg), prec)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Quo(result, big.NewFloat(2)), nil
+}
+
+// standardNormalPDF calculates the probability density function of the standard normal distribution,
+// i.e., φ(z) = exp(-z^2 / 2) / sqrt(2π)
+func standardNormalPDF(ctx context.Context, z *big.Float, prec uint) (*big.Float, error) {
+	exponent := new(big.Float).SetPrec(prec).Mul(z, z)
+	exponent.Quo(exponent, big.NewFloat(-2))
+
+	result, err := exp(ctx, exponent, prec)
+	if err != nil {
+		return nil, err
+	}
+
+	norm, err := pi(ctx, prec)
+	if err != nil {
+		return nil, err
+	}
+	norm.Mul(norm, big.NewFloat(2)).Sqrt(norm)
+
+	return result.Quo(result, norm), nil
+}
+
+// studentTCDF calculates the cumulative distribution function of Student's t-distribution
+// with df degrees of freedom using the closed forms for integer degrees of freedom.
+// With θ = atan(t / sqrt(df)), the probability A = P(|T| <= |t|) is given by:
+// for even df: A = sin θ * (1 + 1/2 cos²θ + (1*3)/(2*4) cos⁴θ + ... + (1*3*...*(df-3))/(2*4*...*(df-2)) cos^(df-2)θ)
+// for odd df: A = 2/π * (θ + sin θ cos θ * (1 + 2/3 cos²θ + ... + (2*4*...*(df-3))/(1*3*...*(df-2)) cos^(df-3)θ))
+// and F(t) = 1/2 + sign(t) * A / 2
+func studentTCDF(ctx context.Context, t *big.Float, df *big.Int, prec uint) (*big.Float, error) {
+	if t.IsInf() {
+		return big.NewFloat(float64(t.Sign()+1) / 2), nil
+	}
+
+	abs := new(big.Float).SetPrec(prec).Abs(t)
+	nu := new(big.Float).SetPrec(prec).SetInt(df)
+
+	// sin θ = t / sqrt(df + t²), cos²θ = df / (df + t²)
+	radius := new(big.Float).SetPrec(prec).Mul(abs, abs)
+	radius.Add(radius, nu)
+	cosSquare := new(big.Float).SetPrec(prec).Quo(nu, radius)
+	sin := new(big.Float).SetPrec(prec).Quo(abs, radius.Sqrt(radius))
+
+	// sum the series with the coefficient ratios (2j-1)/(2j) for even and (2j)/(2j+1) for odd df
+	odd := df.Bit(0) == 1
+	sum := new(big.Float).SetPrec(prec)
+	term := new(big.Float).SetPrec(prec).SetInt64(1)
+	last := new(big.Int).Rsh(new(big.Int).Sub(df, big.NewInt(2)), 1) // (df-2)/2 for even, (df-3)/2 for odd df
+	for j := big.NewInt(0); j.Cmp(last) <= 0; {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		sum.Add(sum, term)
+		j.Add(j, big.NewInt(1))
+
+		numerator := new(big.Int).Lsh(j, 1) // 2j
+		denominator := new(big.Int).Lsh(j, 1)
+		if odd {
+			denominator.Add(denominator, big.NewInt(1)) // 2j/(2j+1)
+		} else {
+			numerator.Sub(numerator, big.NewInt(1)) // (2j-1)/(2j)
+		}
+
+		term.Mul(term, cosSquare).Mul(term, new(big.Float).SetInt(numerator)).Quo(term, new(big.Float).SetInt(denominator))
+	}
+
+	result := new(big.Float).SetPrec(prec)
+	if odd {
+		theta, err := atan(ctx, new(big.Float).SetPrec(prec).Quo(abs, new(big.Float).SetPrec(prec).Sqrt(nu)), prec)
+		if err != nil {
+			return nil, err
+		}
+
+		piValue, err := pi(ctx, prec)
+		if err != nil {
+			return nil, err
+		}
+
+		// A = 2/π * (θ + sin θ * cos θ * sum)
+		cos := new(big.Float).SetPrec(prec).Sqrt(cosSquare)
+		result.Mul(sin, cos).Mul(result, sum).Add(result, theta)
+		result.Mul(result, big.NewFloat(2)).Quo(result, piValue)
+	} else {
+		// A = sin θ * sum
+		result.Mul(sin, sum)
+	}
+
+	// F(t) = (1 + sign(t) * A) / 2
+	if t.Sign() < 0 {
+		result.Neg(result)
+	}
+
+	return result.Add(result, big.NewFloat(1)).Quo(result, big.NewFloat(2)), nil
+}
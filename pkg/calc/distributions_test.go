@@ -0,0 +1,70 @@
+package calc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestDistributions(t *testing.T) {
+	f := big.NewFloat
+
+	for _, tt := range []struct {
+		name string
+		expr string
+		fn   func() (*big.Float, error)
+		want string
+	}{
+		{"test#1", "normpdf(0)", func() (*big.Float, error) { return NormalPDF(context.TODO(), f(0), f(0), f(1)) }, "0.398942280401"},
+		{"test#2", "normpdf(12, 10, 2)", func() (*big.Float, error) { return NormalPDF(context.TODO(), f(12), f(10), f(2)) }, "0.12098536226"},
+		{"test#3", "normcdf(-1.96, 1.96)", func() (*big.Float, error) { return NormalCDF(context.TODO(), f(-1.96), f(1.96), f(0), f(1)) }, "0.950004209704"},
+		{"test#4", "normcdf(10, 11)", func() (*big.Float, error) { return NormalCDF(context.TODO(), f(10), f(11), f(0), f(1)) }, "7.6196619582e-24"},
+		{"test#5", "invnorm(0.975)", func() (*big.Float, error) { return InverseNormal(context.TODO(), f(0.975), f(0), f(1)) }, "1.95996398454"},
+		{"test#6", "invnorm(0.5, 100, 15)", func() (*big.Float, error) { return InverseNormal(context.TODO(), f(0.5), f(100), f(15)) }, "100"},
+		{"test#7", "binompdf(10, 0.5, 5)", func() (*big.Float, error) { return BinomialPDF(context.TODO(), f(10), f(0.5), f(5)) }, "0.24609375"},
+		{"test#8", "binomcdf(10, 0.5, 5)", func() (*big.Float, error) { return BinomialCDF(context.TODO(), f(10), f(0.5), f(5)) }, "0.623046875"},
+		{"test#9", "binomcdf(10, 0.5, 10)", func() (*big.Float, error) { return BinomialCDF(context.TODO(), f(10), f(0.5), f(10)) }, "1"},
+		{"test#10", "poissonpdf(3, 2)", func() (*big.Float, error) { return PoissonPDF(context.TODO(), f(3), f(2)) }, "0.224041807655"},
+		{"test#11", "poissoncdf(3, 2)", func() (*big.Float, error) { return PoissonCDF(context.TODO(), f(3), f(2)) }, "0.423190081127"},
+		{"test#12", "tcdf(-1, 1, 1)", func() (*big.Float, error) { return StudentTCDF(context.TODO(), f(-1), f(1), f(1)) }, "0.5"},
+		{"test#13", "tcdf(-2, 2, 5)", func() (*big.Float, error) { return StudentTCDF(context.TODO(), f(-2), f(2), f(5)) }, "0.89806052117"},
+		{"test#14", "tcdf(-10^99, 2, 2)", func() (*big.Float, error) { return StudentTCDF(context.TODO(), f(-1e99), f(2), f(2)) }, "0.908248290464"},
+		{"test#15", "chi2cdf(0, 1, 1)", func() (*big.Float, error) { return ChiSquareCDF(context.TODO(), f(0), f(1), f(1)) }, "0.682689492137"},
+		{"test#16", "chi2cdf(0, 2, 2)", func() (*big.Float, error) { return ChiSquareCDF(context.TODO(), f(0), f(2), f(2)) }, "0.632120558829"},
+		{"test#17", "chi2cdf(0, 3, 3)", func() (*big.Float, error) { return ChiSquareCDF(context.TODO(), f(0), f(3), f(3)) }, "0.608374823729"},
+		{"test#18", "chi2cdf(0, 10^99, 4)", func() (*big.Float, error) { return ChiSquareCDF(context.TODO(), f(0), f(1e99), f(4)) }, "1"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := tt.fn(); err != nil {
+				t.Errorf("Error calculating %s: %v", tt.expr, err)
+			} else if text := got.Text('g', 12); text != tt.want {
+				t.Errorf("%s = %s, want %s", tt.expr, text, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistributionsDomain(t *testing.T) {
+	f := big.NewFloat
+
+	for _, tt := range []struct {
+		name string
+		expr string
+		fn   func() (*big.Float, error)
+	}{
+		{"test#1", "normpdf(0, 0, 0)", func() (*big.Float, error) { return NormalPDF(context.TODO(), f(0), f(0), f(0)) }},
+		{"test#2", "normcdf(1, -1)", func() (*big.Float, error) { return NormalCDF(context.TODO(), f(1), f(-1), f(0), f(1)) }},
+		{"test#3", "invnorm(1)", func() (*big.Float, error) { return InverseNormal(context.TODO(), f(1), f(0), f(1)) }},
+		{"test#4", "binompdf(10, 1.5, 5)", func() (*big.Float, error) { return BinomialPDF(context.TODO(), f(10), f(1.5), f(5)) }},
+		{"test#5", "poissonpdf(-1, 2)", func() (*big.Float, error) { return PoissonPDF(context.TODO(), f(-1), f(2)) }},
+		{"test#6", "tcdf(-1, 1, 0.5)", func() (*big.Float, error) { return StudentTCDF(context.TODO(), f(-1), f(1), f(0.5)) }},
+		{"test#7", "chi2cdf(0, 1, 0)", func() (*big.Float, error) { return ChiSquareCDF(context.TODO(), f(0), f(1), f(0)) }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := tt.fn(); !errors.Is(err, ErrDomain) {
+				t.Errorf("%s = %v, %v, want domain error", tt.expr, got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package calc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+)
+
+// Determinant calculates the determinant of the square matrix a.
+// The elimination is carried out exactly using rational arithmetic.
+func Determinant(ctx context.Context, a [][]*big.Float) (*big.Float, error) {
+	rows, cols, err := dimensions("det", a)
+	if err != nil {
+		return nil, err
+	}
+
+	if rows != cols {
+		return nil, fmt.Errorf("det function requires a square matrix, got %dx%d", rows, cols)
+	}
+
+	m, err := rationals("det", a)
+	if err != nil {
+		return nil, err
+	}
+
+	_, det, err := eliminate(ctx, m, cols)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Float).SetPrec(precision(flatten(a)...)).SetRat(det), nil
+}
+
+// Inverse calculates the inverse of the square matrix a using Gauss-Jordan elimination on [a | I].
+// The elimination is carried out exactly using rational arithmetic, only the result is rounded.
+func Inverse(ctx context.Context, a [][]*big.Float) ([][]*big.Float, error) {
+	rows, cols, err := dimensions("inv", a)
+	if err != nil {
+		return nil, err
+	}
+
+	if rows != cols {
+		return nil, fmt.Errorf("inv function requires a square matrix, got %dx%d", rows, cols)
+	}
+
+	m, err := rationals("inv", a)
+	if err != nil {
+		return nil, err
+	}
+
+	// augment with the identity matrix
+	for i := range m {
+		for j := 0; j < rows; j++ {
+			if i == j {
+				m[i] = append(m[i], big.NewRat(1, 1))
+			} else {
+				m[i] = append(m[i], new(big.Rat))
+			}
+		}
+	}
+
+	if pivots, _, err := eliminate(ctx, m, cols); err != nil {
+		return nil, err
+	} else if len(pivots) < rows {
+		return nil, fmt.Errorf("matrix is singular")
+	}
+
+	prec := precision(flatten(a)...)
+	result := make([][]*big.Float, rows)
+	for i := range m {
+		result[i] = fromRationals(m[i][cols:], prec)
+	}
+
+	return result, nil
+}
+
+// MatrixMultiply calculates the matrix product of a and b.
+// The number of columns of a must be equal to the number of rows of b.
+func MatrixMultiply(ctx context.Context, a, b [][]*big.Float) ([][]*big.Float, error) {
+	rowsA, colsA, err := dimensions("matrix multiplication", a)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsB, colsB, err := dimensions("matrix multiplication", b)
+	if err != nil {
+		return nil, err
+	}
+
+	if colsA != rowsB {
+		return nil, fmt.Errorf("matrix multiplication of %dx%d and %dx%d matrices is undefined", rowsA, colsA, rowsB, colsB)
+	}
+
+	prec := precision(append(flatten(a), flatten(b)...)...)
+	result := make([][]*big.Float, rowsA)
+	for i := range result {
+		result[i] = make([]*big.Float, colsB)
+		for j := range result[i] {
+			column := make([]*big.Float, rowsB)
+			for k := range column {
+				column[k] = b[k][j]
+			}
+
+			sum, err := dot(ctx, a[i], column)
+			if err != nil {
+				return nil, err
+			}
+			result[i][j] = sum.SetPrec(prec)
+		}
+	}
+
+	return result, nil
+}
+
+// Rank calculates the rank of the matrix a, i.e., the number of linearly independent rows.
+// The elimination is carried out exactly using rational arithmetic.
+func Rank(ctx context.Context, a [][]*big.Float) (*big.Float, error) {
+	_, cols, err := dimensions("rank", a)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := rationals("rank", a)
+	if err != nil {
+		return nil, err
+	}
+
+	pivots, _, err := eliminate(ctx, m, cols)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Float).SetInt64(int64(len(pivots))), nil
+}
+
+// Solve solves the system of linear equations a * x = b for x.
+// The matrix a must be square and regular, the elimination is carried out exactly using rational arithmetic.
+func Solve(ctx context.Context, a [][]*big.Float, b []*big.Float) ([]*big.Float, error) {
+	rows, cols, err := dimensions("solve", a)
+	if err != nil {
+		return nil, err
+	}
+
+	if rows != cols {
+		return nil, fmt.Errorf("solve function requires a square matrix, got %dx%d", rows, cols)
+	}
+
+	if len(b) != rows {
+		return nil, fmt.Errorf("solve function requires a vector of length %d, got %d", rows, len(b))
+	}
+
+	m, err := rationals("solve", append(append([][]*big.Float{}, a...), b))
+	if err != nil {
+		return nil, err
+	}
+
+	// augment with the right-hand side
+	rhs := m[rows]
+	m = m[:rows]
+	for i := range m {
+		m[i] = append(m[i], rhs[i])
+	}
+
+	if pivots, _, err := eliminate(ctx, m, cols); err != nil {
+		return nil, err
+	} else if len(pivots) < rows {
+		return nil, fmt.Errorf("the system of equations has no unique solution")
+	}
+
+	solution := make([]*big.Rat, rows)
+	for i := range m {
+		solution[i] = m[i][cols]
+	}
+
+	return fromRationals(solution, precision(append(flatten(a), b...)...)), nil
+}
+
+// Transpose calculates the transpose of the matrix a.
+func Transpose(ctx context.Context, a [][]*big.Float) ([][]*big.Float, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, cols, err := dimensions("transpose", a)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([][]*big.Float, cols)
+	for j := range result {
+		result[j] = make([]*big.Float, rows)
+		for i := range a {
+			result[j][i] = a[i][j]
+		}
+	}
+
+	return result, nil
+}
+
+// dimensions returns the number of rows and columns of the matrix a.
+// It fails if a is empty or if its rows differ in length.
+func dimensions(name string, a [][]*big.Float) (rows, cols int, err error) {
+	if len(a) == 0 || len(a[0]) == 0 {
+		return 0, 0, fmt.Errorf("%s requires a non-empty matrix", name)
+	}
+
+	for i := range a {
+		if len(a[i]) != len(a[0]) {
+			return 0, 0, fmt.Errorf("%s requires a matrix with rows of equal length", name)
+		}
+	}
+
+	return len(a), len(a[0]), nil
+}
+
+// eliminate transforms m into the reduced row echelon form in place using Gauss-Jordan elimination
+// on the first n columns. It returns the pivot columns and the determinant of the leading n×n block,
+// which is 0 if the block is singular.
+func eliminate(ctx context.Context, m [][]*big.Rat, n int) ([]int, *big.Rat, error) {
+	det := big.NewRat(1, 1)
+	var pivots []int
+
+	for row, col := 0, 0; col < n && row < len(m); col++ {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		pivot := -1
+		for i := row; i < len(m); i++ {
+			if m[i][col].Sign() != 0 {
+				pivot = i
+				break
+			}
+		}
+
+		if pivot < 0 {
+			continue
+		}
+
+		if pivot != row {
+			m[row], m[pivot] = m[pivot], m[row]
+			det.Neg(det)
+		}
+
+		// normalize the pivot row
+		det.Mul(det, m[row][col])
+		factor := new(big.Rat).Inv(m[row][col])
+		for k := range m[row] {
+			m[row][k].Mul(m[row][k], factor)
+		}
+
+		// eliminate the pivot column in all other rows
+		for i := range m {
+			if i == row || m[i][col].Sign() == 0 {
+				continue
+			}
+
+			factor.Set(m[i][col])
+			for k := range m[i] {
+				m[i][k].Sub(m[i][k], new(big.Rat).Mul(factor, m[row][k]))
+			}
+		}
+
+		pivots = append(pivots, col)
+		row++
+	}
+
+	if len(pivots) < n {
+		det.SetInt64(0)
+	}
+
+	return pivots, det, nil
+}
+
+// flatten returns all entries of the matrix a row by row.
+func flatten(a [][]*big.Float) []*big.Float {
+	var result []*big.Float
+	for _, row := range a {
+		result = append(result, row...)
+	}
+
+	return result
+}
+
+// fromRationals converts the rational numbers to floating-point numbers with the given precision.
+func fromRationals(rats []*big.Rat, prec uint) []*big.Float {
+	result := make([]*big.Float, len(rats))
+	for i, r := range rats {
+		result[i] = new(big.Float).SetPrec(prec).SetRat(r)
+	}
+
+	return result
+}
+
+// rationals converts the entries of the matrix a to exact rational numbers.
+func rationals(name string, a [][]*big.Float) ([][]*big.Rat, error) {
+	result := make([][]*big.Rat, len(a))
+	for i := range a {
+		result[i] = make([]*big.Rat, len(a[i]))
+		for j := range a[i] {
+			if a[i][j].IsInf() {
+				return nil, fmt.Errorf("%s requires finite matrix entries", name)
+			}
+
+			result[i][j], _ = a[i][j].Rat(nil)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,148 @@
+package calc
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestMatrices(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		args          [][]float64
+		wantDet       string
+		wantInverse   string
+		wantRank      string
+		wantTranspose string
+	}{
+		{"test#1", [][]float64{{1, 2}, {3, 4}}, "-2", "[[-2 1] [1.5 -0.5]]", "2", "[[1 3] [2 4]]"},
+		{"test#2", [][]float64{{2, 0, 0}, {0, 3, 0}, {0, 0, 4}}, "24", "[[0.5 0 0] [0 0.3333333333 0] [0 0 0.25]]", "3", "[[2 0 0] [0 3 0] [0 0 4]]"},
+		{"test#3", [][]float64{{1, 2}, {2, 4}}, "0", "", "1", "[[1 2] [2 4]]"},
+		{"test#4", [][]float64{{0, 1}, {1, 0}}, "-1", "[[0 1] [1 0]]", "2", "[[0 1] [1 0]]"},
+		{"test#5", [][]float64{{1, 2, 3}, {4, 5, 6}}, "", "", "2", "[[1 4] [2 5] [3 6]]"},
+		{"test#6", [][]float64{{0.1, 0.2}, {0.3, 0.6}}, "0", "", "1", "[[0.1 0.3] [0.2 0.6]]"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			a := matrix(tt.args...)
+
+			got, err := Determinant(context.TODO(), a)
+			if tt.wantDet == "" {
+				if err == nil {
+					t.Errorf("Determinant() = %v, want error", got)
+				}
+			} else if err != nil {
+				t.Errorf("Error calculating det: %v", err)
+			} else if text := got.Text('g', 10); text != tt.wantDet {
+				t.Errorf("Determinant() = %s, want %s", text, tt.wantDet)
+			}
+
+			inverse, err := Inverse(context.TODO(), a)
+			if tt.wantInverse == "" {
+				if err == nil {
+					t.Errorf("Inverse() = %v, want error", inverse)
+				}
+			} else if err != nil {
+				t.Errorf("Error calculating inv: %v", err)
+			} else if text := matrixText(inverse); text != tt.wantInverse {
+				t.Errorf("Inverse() = %s, want %s", text, tt.wantInverse)
+			}
+
+			if got, err := Rank(context.TODO(), a); err != nil {
+				t.Errorf("Error calculating rank: %v", err)
+			} else if text := got.Text('g', 10); text != tt.wantRank {
+				t.Errorf("Rank() = %s, want %s", text, tt.wantRank)
+			}
+
+			if got, err := Transpose(context.TODO(), a); err != nil {
+				t.Errorf("Error calculating transpose: %v", err)
+			} else if text := matrixText(got); text != tt.wantTranspose {
+				t.Errorf("Transpose() = %s, want %s", text, tt.wantTranspose)
+			}
+		})
+	}
+}
+
+func TestMatrixMultiply(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		a, b [][]float64
+		want string
+	}{
+		{"test#1", [][]float64{{1, 2}, {3, 4}}, [][]float64{{5, 6}, {7, 8}}, "[[19 22] [43 50]]"},
+		{"test#2", [][]float64{{1, 2, 3}}, [][]float64{{4}, {5}, {6}}, "[[32]]"},
+		{"test#3", [][]float64{{1, 2}}, [][]float64{{1, 2}}, ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MatrixMultiply(context.TODO(), matrix(tt.a...), matrix(tt.b...))
+			if tt.want == "" {
+				if err == nil {
+					t.Errorf("MatrixMultiply() = %v, want error", got)
+				}
+			} else if err != nil {
+				t.Errorf("Error multiplying matrices: %v", err)
+			} else if text := matrixText(got); text != tt.want {
+				t.Errorf("MatrixMultiply() = %s, want %s", text, tt.want)
+			}
+		})
+	}
+}
+
+func TestSolve(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		a    [][]float64
+		b    []float64
+		want string
+	}{
+		{"test#1", [][]float64{{2, 1}, {1, 3}}, []float64{3, 5}, "[0.8 1.4]"},
+		{"test#2", [][]float64{{1, 1, 1}, {0, 2, 5}, {2, 5, -1}}, []float64{6, -4, 27}, "[5 3 -2]"},
+		{"test#3", [][]float64{{1, 2}, {2, 4}}, []float64{1, 2}, ""},
+		{"test#4", [][]float64{{1, 2}, {3, 4}}, []float64{1}, ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Solve(context.TODO(), matrix(tt.a...), floats(tt.b...))
+			if tt.want == "" {
+				if err == nil {
+					t.Errorf("Solve() = %v, want error", got)
+				}
+			} else if err != nil {
+				t.Errorf("Error solving system: %v", err)
+			} else if text := matrixText([][]*big.Float{got}); text != "["+tt.want+"]" {
+				t.Errorf("Solve() = %s, want %s", text, tt.want)
+			}
+		})
+	}
+}
+
+func TestInversePrecision(t *testing.T) {
+	third, _ := new(big.Float).SetPrec(200).SetString("3")
+	got, err := Inverse(context.TODO(), [][]*big.Float{{third}})
+	if err != nil {
+		t.Fatalf("Error calculating inv: %v", err)
+	}
+
+	if text, want := got[0][0].Text('g', 60), "0."+strings.Repeat("3", 60); text != want {
+		t.Errorf("Inverse() = %s, want %s", text, want)
+	}
+}
+
+// matrix converts rows of float64 values to a matrix of big.Float values.
+func matrix(rows ...[]float64) [][]*big.Float {
+	result := make([][]*big.Float, len(rows))
+	for i, row := range rows {
+		result[i] = floats(row...)
+	}
+
+	return result
+}
+
+// matrixText formats a matrix rounded to 10 significant digits for comparison in tests.
+func matrixText(m [][]*big.Float) string {
+	rows := make([]string, len(m))
+	for i, row := range m {
+		rows[i] = rounded(row)
+	}
+
+	return "[" + strings.Join(rows, " ") + "]"
+}
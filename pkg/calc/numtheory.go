@@ -0,0 +1,445 @@
+package calc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// primalityRounds is the number of Miller-Rabin rounds used in addition to the Baillie-PSW test.
+// The test is exact for all numbers below 2^64.
+const primalityRounds = 20
+
+// trialDivisionLimit is the upper bound for the trial division performed before Pollard's rho method.
+const trialDivisionLimit = 1 << 10
+
+// Binomial calculates the binomial coefficient nCr = n! / (k! * (n-k)!) using the multiplicative formula
+// nCr = n/1 * (n-1)/2 * ... * (n-k+1)/k
+func Binomial(ctx context.Context, n, k *big.Float) (*big.Float, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	nInt, kInt, err := combinatorics("nCr", n, k)
+	if err != nil {
+		return nil, err
+	}
+
+	if kInt.Cmp(nInt) > 0 {
+		return big.NewFloat(0), nil
+	}
+
+	// use the symmetry nCr = nC(n-r) to reduce the number of iterations
+	if rest := new(big.Int).Sub(nInt, kInt); rest.Cmp(kInt) < 0 {
+		kInt = rest
+	}
+
+	result := big.NewInt(1)
+	for i := big.NewInt(1); i.Cmp(kInt) <= 0; i.Add(i, big.NewInt(1)) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		result.Mul(result, new(big.Int).Sub(nInt, new(big.Int).Sub(i, big.NewInt(1))))
+		result.Quo(result, i) // the division is always exact
+	}
+
+	return new(big.Float).SetInt(result), nil
+}
+
+// Divisors calculates all positive divisors of n in ascending order.
+func Divisors(ctx context.Context, n *big.Float) ([]*big.Float, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	nInt, err := integer("divisors", n)
+	if err != nil {
+		return nil, err
+	}
+
+	if nInt.Sign() == 0 {
+		return nil, fmt.Errorf("divisors of 0 are not defined")
+	}
+
+	factors, err := factorize(ctx, new(big.Int).Abs(nInt))
+	if err != nil {
+		return nil, err
+	}
+
+	// multiply every known divisor with every power of each distinct prime factor
+	divisors := []*big.Int{big.NewInt(1)}
+	for i := 0; i < len(factors); {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		j := i
+		for j < len(factors) && factors[j].Cmp(factors[i]) == 0 {
+			j++
+		}
+
+		current := len(divisors)
+		for k := 0; k < current; k++ {
+			power := new(big.Int).Set(divisors[k])
+			for e := i; e < j; e++ {
+				power = new(big.Int).Mul(power, factors[i])
+				divisors = append(divisors, power)
+			}
+		}
+
+		i = j
+	}
+
+	sort.Slice(divisors, func(i, j int) bool { return divisors[i].Cmp(divisors[j]) < 0 })
+
+	return toFloats(divisors), nil
+}
+
+// Factor calculates the prime factorization of n.
+// The prime factors are returned in ascending order and are repeated according to their multiplicity,
+// e.g. Factor(360) = [2, 2, 2, 3, 3, 5].
+// Small factors are found using trial division, large ones using Pollard's rho method.
+func Factor(ctx context.Context, n *big.Float) ([]*big.Float, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	nInt, err := integer("factor", n)
+	if err != nil {
+		return nil, err
+	}
+
+	if nInt.Sign() <= 0 {
+		return nil, fmt.Errorf("factorization is only defined for positive integers")
+	}
+
+	factors, err := factorize(ctx, nInt)
+	if err != nil {
+		return nil, err
+	}
+
+	return toFloats(factors), nil
+}
+
+// Fibonacci calculates the n-th Fibonacci number using the fast doubling method:
+// F(2k) = F(k) * (2*F(k+1) - F(k)) and F(2k+1) = F(k)^2 + F(k+1)^2
+// Negative indices are supported using F(-n) = (-1)^(n+1) * F(n).
+func Fibonacci(ctx context.Context, n *big.Float) (*big.Float, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	nInt, err := integer("fib", n)
+	if err != nil {
+		return nil, err
+	}
+
+	index := new(big.Int).Abs(nInt)
+	a, b := big.NewInt(0), big.NewInt(1) // F(0), F(1)
+	for i := index.BitLen() - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// c = F(2k), d = F(2k+1)
+		c := new(big.Int).Lsh(b, 1)
+		c.Sub(c, a)
+		c.Mul(c, a)
+		d := new(big.Int).Mul(a, a)
+		d.Add(d, new(big.Int).Mul(b, b))
+
+		if index.Bit(i) == 0 {
+			a, b = c, d
+		} else {
+			a, b = d, c.Add(c, d)
+		}
+	}
+
+	if nInt.Sign() < 0 && index.Bit(0) == 0 {
+		a.Neg(a)
+	}
+
+	return new(big.Float).SetInt(a), nil
+}
+
+// IsPrime reports whether n is a prime number.
+// It returns 1 for primes and 0 otherwise.
+func IsPrime(ctx context.Context, n *big.Float) (*big.Float, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	nInt, err := integer("isprime", n)
+	if err != nil {
+		return nil, err
+	}
+
+	if nInt.ProbablyPrime(primalityRounds) {
+		return big.NewFloat(1), nil
+	}
+
+	return big.NewFloat(0), nil
+}
+
+// ModInverse calculates the modular multiplicative inverse x of a modulo m, such that a*x ≡ 1 (mod m).
+func ModInverse(ctx context.Context, a, m *big.Float) (*big.Float, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	aInt, err := integer("modinv", a)
+	if err != nil {
+		return nil, err
+	}
+
+	mInt, err := integer("modinv", m)
+	if err != nil {
+		return nil, err
+	}
+
+	if mInt.Sign() <= 0 {
+		return nil, fmt.Errorf("modulus must be a positive integer")
+	}
+
+	inverse := new(big.Int).ModInverse(new(big.Int).Mod(aInt, mInt), mInt)
+	if inverse == nil {
+		return nil, fmt.Errorf("%s has no inverse modulo %s", aInt, mInt)
+	}
+
+	return new(big.Float).SetInt(inverse), nil
+}
+
+// ModPow calculates base^exponent mod modulus using binary exponentiation.
+// Negative exponents are supported if base is invertible modulo modulus.
+func ModPow(ctx context.Context, base, exponent, modulus *big.Float) (*big.Float, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ints, err := integers("modpow", base, exponent, modulus)
+	if err != nil {
+		return nil, err
+	}
+
+	baseInt, expInt, modInt := ints[0], ints[1], ints[2]
+	if modInt.Sign() <= 0 {
+		return nil, fmt.Errorf("modulus must be a positive integer")
+	}
+
+	if expInt.Sign() < 0 { // Negative exponent: use the modular inverse of the base
+		inverse := new(big.Int).ModInverse(new(big.Int).Mod(baseInt, modInt), modInt)
+		if inverse == nil {
+			return nil, fmt.Errorf("%s has no inverse modulo %s", baseInt, modInt)
+		}
+		baseInt, expInt = inverse, new(big.Int).Neg(expInt)
+	}
+
+	return new(big.Float).SetInt(new(big.Int).Exp(baseInt, expInt, modInt)), nil
+}
+
+// NextPrime calculates the smallest prime number greater than n.
+func NextPrime(ctx context.Context, n *big.Float) (*big.Float, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	nInt, err := integer("nextprime", n)
+	if err != nil {
+		return nil, err
+	}
+
+	if nInt.Cmp(big.NewInt(2)) < 0 {
+		return big.NewFloat(2), nil
+	}
+
+	// start with the next odd number and skip even candidates
+	candidate := new(big.Int).Add(nInt, big.NewInt(1))
+	if candidate.Bit(0) == 0 {
+		candidate.Add(candidate, big.NewInt(1))
+	}
+
+	for ; !candidate.ProbablyPrime(primalityRounds); candidate.Add(candidate, big.NewInt(2)) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return new(big.Float).SetInt(candidate), nil
+}
+
+// Permutations calculates the number of k-permutations of n, nPr = n! / (n-k)! = n * (n-1) * ... * (n-k+1)
+func Permutations(ctx context.Context, n, k *big.Float) (*big.Float, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	nInt, kInt, err := combinatorics("nPr", n, k)
+	if err != nil {
+		return nil, err
+	}
+
+	if kInt.Cmp(nInt) > 0 {
+		return big.NewFloat(0), nil
+	}
+
+	result := big.NewInt(1)
+	stop := new(big.Int).Sub(nInt, kInt)
+	for i := new(big.Int).Set(nInt); i.Cmp(stop) > 0; i.Sub(i, big.NewInt(1)) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		result.Mul(result, i)
+	}
+
+	return new(big.Float).SetInt(result), nil
+}
+
+// Totient calculates Euler's totient function φ(n), i.e., the number of integers in [1, n] coprime to n,
+// using the product formula φ(n) = n * Π(1 - 1/p) over all distinct prime factors p of n.
+func Totient(ctx context.Context, n *big.Float) (*big.Float, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	nInt, err := integer("totient", n)
+	if err != nil {
+		return nil, err
+	}
+
+	if nInt.Sign() <= 0 {
+		return nil, fmt.Errorf("totient is only defined for positive integers")
+	}
+
+	factors, err := factorize(ctx, nInt)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(big.Int).Set(nInt)
+	for i, p := range factors {
+		if i > 0 && factors[i-1].Cmp(p) == 0 {
+			continue // only distinct prime factors
+		}
+
+		result.Quo(result, p)
+		result.Mul(result, new(big.Int).Sub(p, big.NewInt(1)))
+	}
+
+	return new(big.Float).SetInt(result), nil
+}
+
+// combinatorics converts the arguments of nCr and nPr into integers and validates them.
+func combinatorics(name string, n, k *big.Float) (*big.Int, *big.Int, error) {
+	ints, err := integers(name, n, k)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if ints[0].Sign() < 0 || ints[1].Sign() < 0 {
+		return nil, nil, fmt.Errorf("%s is only defined for non-negative integers", name)
+	}
+
+	return ints[0], ints[1], nil
+}
+
+// factorize calculates the prime factors of n > 0 in ascending order.
+func factorize(ctx context.Context, n *big.Int) ([]*big.Int, error) {
+	var factors []*big.Int
+
+	rest := new(big.Int).Set(n)
+	quotient, remainder := new(big.Int), new(big.Int)
+
+	// find small factors using trial division
+	for p := int64(2); p < trialDivisionLimit && rest.Cmp(big.NewInt(1)) > 0; p++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for divisor := big.NewInt(p); ; {
+			if quotient.QuoRem(rest, divisor, remainder); remainder.Sign() != 0 {
+				break
+			}
+
+			factors = append(factors, divisor)
+			rest.Set(quotient)
+		}
+	}
+
+	// split the remaining cofactor using Pollard's rho method
+	stack := []*big.Int{rest}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		switch {
+		case current.Cmp(big.NewInt(1)) <= 0:
+			continue
+
+		case current.ProbablyPrime(primalityRounds):
+			factors = append(factors, current)
+			continue
+
+		}
+
+		divisor, err := pollardRho(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+
+		stack = append(stack, divisor, new(big.Int).Quo(current, divisor))
+	}
+
+	sort.Slice(factors, func(i, j int) bool { return factors[i].Cmp(factors[j]) < 0 })
+
+	return factors, nil
+}
+
+// pollardRho finds a non-trivial divisor of the composite number n
+// using Pollard's rho method with Floyd's cycle detection and the polynomial f(x) = x^2 + c mod n.
+func pollardRho(ctx context.Context, n *big.Int) (*big.Int, error) {
+	if n.Bit(0) == 0 {
+		return big.NewInt(2), nil
+	}
+
+	one := big.NewInt(1)
+	for c := int64(1); ; c++ {
+		constant := big.NewInt(c)
+		step := func(x *big.Int) {
+			x.Mul(x, x)
+			x.Add(x, constant)
+			x.Mod(x, n)
+		}
+
+		x, y, divisor, diff := big.NewInt(2), big.NewInt(2), big.NewInt(1), new(big.Int)
+		for i := 0; divisor.Cmp(one) == 0; i++ {
+			if i%128 == 0 {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+			}
+
+			step(x)
+			step(y)
+			step(y)
+			divisor.GCD(nil, nil, diff.Abs(diff.Sub(x, y)), n)
+		}
+
+		if divisor.Cmp(n) != 0 {
+			return divisor, nil
+		}
+		// the cycle closed without finding a divisor, retry with another constant
+	}
+}
+
+// toFloats converts a list of big.Int values into a list of big.Float values.
+func toFloats(ints []*big.Int) []*big.Float {
+	floats := make([]*big.Float, len(ints))
+	for i, v := range ints {
+		floats[i] = new(big.Float).SetInt(v)
+	}
+
+	return floats
+}
@@ -0,0 +1,204 @@
+package calc
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestBinomialAndPermutations(t *testing.T) {
+	type args struct {
+		n, k int
+	}
+
+	for _, tt := range []struct {
+		name          string
+		args          args
+		wantBinomial  int
+		wantPermuting int
+	}{
+		{"test#1", args{0, 0}, 1, 1},
+		{"test#2", args{5, 0}, 1, 1},
+		{"test#3", args{5, 2}, 10, 20},
+		{"test#4", args{10, 3}, 120, 720},
+		{"test#5", args{10, 7}, 120, 604800},
+		{"test#6", args{3, 5}, 0, 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			n, k := big.NewFloat(float64(tt.args.n)), big.NewFloat(float64(tt.args.k))
+			if got, err := Binomial(context.TODO(), n, k); err != nil {
+				t.Errorf("Error calculating nCr(%d, %d): %v", tt.args.n, tt.args.k, err)
+			} else if got.Cmp(big.NewFloat(float64(tt.wantBinomial))) != 0 {
+				t.Errorf("Binomial(%d, %d) = %v, want %d", tt.args.n, tt.args.k, got, tt.wantBinomial)
+			}
+
+			if got, err := Permutations(context.TODO(), n, k); err != nil {
+				t.Errorf("Error calculating nPr(%d, %d): %v", tt.args.n, tt.args.k, err)
+			} else if got.Cmp(big.NewFloat(float64(tt.wantPermuting))) != 0 {
+				t.Errorf("Permutations(%d, %d) = %v, want %d", tt.args.n, tt.args.k, got, tt.wantPermuting)
+			}
+		})
+	}
+}
+
+func TestFactorAndDivisors(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		args         string
+		wantFactors  string
+		wantDivisors string
+	}{
+		{"test#1", "1", "[]", "[1]"},
+		{"test#2", "2", "[2]", "[1 2]"},
+		{"test#3", "12", "[2 2 3]", "[1 2 3 4 6 12]"},
+		{"test#4", "360", "[2 2 2 3 3 5]", "[1 2 3 4 5 6 8 9 10 12 15 18 20 24 30 36 40 45 60 72 90 120 180 360]"},
+		{"test#5", "1000003", "[1000003]", "[1 1000003]"},
+		{"test#6", "600851475143", "[71 839 1471 6857]", ""},
+		{"test#7", "1000000016000000063", "[1000000007 1000000009]", "[1 1000000007 1000000009 1000000016000000063]"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			n, _ := new(big.Float).SetString(tt.args)
+
+			if got, err := Factor(context.TODO(), n); err != nil {
+				t.Errorf("Error calculating factor(%s): %v", tt.args, err)
+			} else if text(got) != tt.wantFactors {
+				t.Errorf("Factor(%s) = %v, want %s", tt.args, got, tt.wantFactors)
+			}
+
+			if tt.wantDivisors == "" {
+				return
+			}
+
+			if got, err := Divisors(context.TODO(), n); err != nil {
+				t.Errorf("Error calculating divisors(%s): %v", tt.args, err)
+			} else if text(got) != tt.wantDivisors {
+				t.Errorf("Divisors(%s) = %v, want %s", tt.args, got, tt.wantDivisors)
+			}
+		})
+	}
+}
+
+func TestFibonacci(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		args int
+		want string
+	}{
+		{"test#1", 0, "0"},
+		{"test#2", 1, "1"},
+		{"test#3", 2, "1"},
+		{"test#4", 10, "55"},
+		{"test#5", -6, "-8"},
+		{"test#6", -7, "13"},
+		{"test#7", 100, "354224848179261915075"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := Fibonacci(context.TODO(), big.NewFloat(float64(tt.args))); err != nil {
+				t.Errorf("Error calculating fib(%d): %v", tt.args, err)
+			} else if got.Text('f', -1) != tt.want {
+				t.Errorf("Fibonacci(%d) = %s, want %s", tt.args, got.Text('f', -1), tt.want)
+			}
+		})
+	}
+}
+
+func TestModularArithmetic(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		fn      func() (*big.Float, error)
+		want    int
+		wantErr bool
+	}{
+		{"test#1", func() (*big.Float, error) {
+			return ModPow(context.TODO(), big.NewFloat(4), big.NewFloat(13), big.NewFloat(497))
+		}, 445, false},
+		{"test#2", func() (*big.Float, error) {
+			return ModPow(context.TODO(), big.NewFloat(3), big.NewFloat(-1), big.NewFloat(11))
+		}, 4, false},
+		{"test#3", func() (*big.Float, error) {
+			return ModPow(context.TODO(), big.NewFloat(3), big.NewFloat(2), big.NewFloat(0))
+		}, 0, true},
+		{"test#4", func() (*big.Float, error) {
+			return ModInverse(context.TODO(), big.NewFloat(3), big.NewFloat(11))
+		}, 4, false},
+		{"test#5", func() (*big.Float, error) {
+			return ModInverse(context.TODO(), big.NewFloat(-3), big.NewFloat(11))
+		}, 7, false},
+		{"test#6", func() (*big.Float, error) {
+			return ModInverse(context.TODO(), big.NewFloat(4), big.NewFloat(8))
+		}, 0, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Error = %v, want error: %t", err, tt.wantErr)
+			} else if err == nil && got.Cmp(big.NewFloat(float64(tt.want))) != 0 {
+				t.Errorf("Result = %v, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrimes(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		args          int
+		wantIsPrime   int
+		wantNextPrime int
+		wantTotient   int
+	}{
+		{"test#1", 1, 0, 2, 1},
+		{"test#2", 2, 1, 3, 1},
+		{"test#3", 9, 0, 11, 6},
+		{"test#4", 13, 1, 17, 12},
+		{"test#5", 36, 0, 37, 12},
+		{"test#6", 7919, 1, 7927, 7918},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			n := big.NewFloat(float64(tt.args))
+
+			if got, err := IsPrime(context.TODO(), n); err != nil {
+				t.Errorf("Error calculating isprime(%d): %v", tt.args, err)
+			} else if got.Cmp(big.NewFloat(float64(tt.wantIsPrime))) != 0 {
+				t.Errorf("IsPrime(%d) = %v, want %d", tt.args, got, tt.wantIsPrime)
+			}
+
+			if got, err := NextPrime(context.TODO(), n); err != nil {
+				t.Errorf("Error calculating nextprime(%d): %v", tt.args, err)
+			} else if got.Cmp(big.NewFloat(float64(tt.wantNextPrime))) != 0 {
+				t.Errorf("NextPrime(%d) = %v, want %d", tt.args, got, tt.wantNextPrime)
+			}
+
+			if got, err := Totient(context.TODO(), n); err != nil {
+				t.Errorf("Error calculating totient(%d): %v", tt.args, err)
+			} else if got.Cmp(big.NewFloat(float64(tt.wantTotient))) != 0 {
+				t.Errorf("Totient(%d) = %v, want %d", tt.args, got, tt.wantTotient)
+			}
+		})
+	}
+}
+
+func TestNumberTheoryCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	n, _ := new(big.Float).SetString("1000000016000000063")
+	if _, err := Factor(ctx, n); err == nil {
+		t.Errorf("Factor() on a canceled context succeeded, want error")
+	}
+
+	if _, err := Fibonacci(ctx, n); err == nil {
+		t.Errorf("Fibonacci() on a canceled context succeeded, want error")
+	}
+}
+
+// text formats a list of numbers for comparison in tests.
+func text(floats []*big.Float) string {
+	texts := make([]string, len(floats))
+	for i, f := range floats {
+		texts[i] = f.Text('f', -1)
+	}
+
+	return "[" + strings.Join(texts, " ") + "]"
+}
@@ -0,0 +1,341 @@
+package calc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// Model is a regression model which can be fitted to data points.
+type Model string
+
+const (
+	ExponentialModel Model = "exponential" // y = a * e^(b*x)
+	LinearModel      Model = "linear"      // y = a + b*x
+	LogarithmicModel Model = "logarithmic" // y = a + b*ln(x)
+	PolynomialModel  Model = "polynomial"  // y = a0 + a1*x + ... + an*x^n
+	PowerModel       Model = "power"       // y = a * x^b
+)
+
+// Models lists all supported regression models.
+var Models = []Model{LinearModel, PolynomialModel, ExponentialModel, LogarithmicModel, PowerModel}
+
+// Regression is the result of fitting a regression model to data points.
+// The coefficients are given in ascending order, i.e., a, b for the two-parameter models
+// and a0, a1, ..., an for the polynomial model.
+// Exponential, logarithmic and power models are fitted by linear least squares on the linearized model,
+// e.g., ln(y) = ln(a) + b*x for the exponential model, and RSquared refers to the linearized model.
+// Residuals are the differences y - f(x) of the original data points.
+type Regression struct {
+	Model        Model
+	Coefficients []*big.Float
+	RSquared     *big.Float
+	Residuals    []*big.Float
+}
+
+// Predict evaluates the fitted model at x.
+func (r *Regression) Predict(ctx context.Context, x *big.Float) (*big.Float, error) {
+	prec := precision(append([]*big.Float{x}, r.Coefficients...)...)
+	result, err := r.predict(ctx, x, prec+guardBits)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.SetPrec(prec), nil
+}
+
+// String returns the equation of the fitted model.
+func (r *Regression) String() string {
+	c := make([]string, len(r.Coefficients))
+	for i, coefficient := range r.Coefficients {
+		c[i] = coefficient.Text('g', 10)
+	}
+
+	switch r.Model {
+	case ExponentialModel:
+		return fmt.Sprintf("y = %s·e^(%s·x)", c[0], c[1])
+
+	case LogarithmicModel:
+		return fmt.Sprintf("y = %s + %s·ln(x)", c[0], c[1])
+
+	case PowerModel:
+		return fmt.Sprintf("y = %s·x^%s", c[0], c[1])
+
+	}
+
+	terms := []string{c[0]}
+	for i := 1; i < len(c); i++ {
+		if i == 1 {
+			terms = append(terms, c[i]+"·x")
+		} else {
+			terms = append(terms, fmt.Sprintf("%s·x^%d", c[i], i))
+		}
+	}
+
+	return "y = " + strings.Join(terms, " + ")
+}
+
+// predict evaluates the fitted model at x with the given precision.
+func (r *Regression) predict(ctx context.Context, x *big.Float, prec uint) (*big.Float, error) {
+	switch r.Model {
+	case ExponentialModel:
+		result, err := exp(ctx, new(big.Float).SetPrec(prec).Mul(r.Coefficients[1], x), prec)
+		if err != nil {
+			return nil, err
+		}
+
+		return result.Mul(result, r.Coefficients[0]), nil
+
+	case LogarithmicModel:
+		result, err := ln(ctx, x, prec)
+		if err != nil {
+			return nil, err
+		}
+
+		return result.Add(result.Mul(result, r.Coefficients[1]), r.Coefficients[0]), nil
+
+	case PowerModel:
+		result, err := ln(ctx, x, prec)
+		if err != nil {
+			return nil, err
+		}
+
+		if result, err = exp(ctx, result.Mul(result, r.Coefficients[1]), prec); err != nil {
+			return nil, err
+		}
+
+		return result.Mul(result, r.Coefficients[0]), nil
+
+	}
+
+	return polynomial(r.Coefficients, x, prec), nil
+}
+
+// Fit fits the given regression model to the data points (xs[i], ys[i]) using the method of least squares.
+// The degree is only used by the polynomial model.
+func Fit(ctx context.Context, model Model, degree int, xs, ys []*big.Float) (*Regression, error) {
+	switch model {
+	case PolynomialModel:
+		if degree < 1 {
+			return nil, fmt.Errorf("polynomial regression requires a degree of at least 1")
+		}
+
+	case ExponentialModel, LinearModel, LogarithmicModel, PowerModel:
+		degree = 1
+
+	default:
+		return nil, fmt.Errorf("unsupported regression model: %q", model)
+
+	}
+
+	if len(xs) != len(ys) {
+		return nil, fmt.Errorf("%s regression requires the same number of x and y values", model)
+	}
+
+	if len(xs) < degree+1 {
+		return nil, fmt.Errorf("%s regression requires at least %d data points", model, degree+1)
+	}
+
+	prec := precision(append(append([]*big.Float{}, xs...), ys...)...)
+	work := prec + guardBits
+
+	// linearize the model
+	us, vs := make([]*big.Float, len(xs)), make([]*big.Float, len(ys))
+	for i := range xs {
+		var err error
+		us[i], vs[i] = xs[i], ys[i]
+
+		if model == LogarithmicModel || model == PowerModel {
+			if us[i], err = ln(ctx, xs[i], work); err != nil {
+				return nil, fmt.Errorf("%s regression requires positive x values: %w", model, err)
+			}
+		}
+
+		if model == ExponentialModel || model == PowerModel {
+			if vs[i], err = ln(ctx, ys[i], work); err != nil {
+				return nil, fmt.Errorf("%s regression requires positive y values: %w", model, err)
+			}
+		}
+	}
+
+	coefficients, err := leastSquares(ctx, degree, us, vs, work)
+	if err != nil {
+		return nil, fmt.Errorf("%s regression: %w", model, err)
+	}
+
+	result := &Regression{
+		Model:        model,
+		Coefficients: coefficients,
+		RSquared:     coefficientOfDetermination(coefficients, us, vs, work),
+		Residuals:    make([]*big.Float, len(xs)),
+	}
+
+	// transform the coefficients back, i.e., a = e^ln(a)
+	if model == ExponentialModel || model == PowerModel {
+		if coefficients[0], err = exp(ctx, coefficients[0], work); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range xs {
+		prediction, err := result.predict(ctx, xs[i], work)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Residuals[i] = prediction.Sub(ys[i], prediction).SetPrec(prec)
+	}
+
+	for _, coefficient := range coefficients {
+		coefficient.SetPrec(prec)
+	}
+	result.RSquared.SetPrec(prec)
+
+	return result, nil
+}
+
+// coefficientOfDetermination calculates r² = 1 - SSres / SStot of the polynomial with given coefficients.
+// If all values are equal, r² is 1.
+func coefficientOfDetermination(coefficients, xs, ys []*big.Float, prec uint) *big.Float {
+	mean := new(big.Float).SetPrec(prec)
+	for _, y := range ys {
+		mean.Add(mean, y)
+	}
+	mean.Quo(mean, big.NewFloat(float64(len(ys))))
+
+	residual, total := new(big.Float).SetPrec(prec), new(big.Float).SetPrec(prec)
+	for i := range xs {
+		diff := polynomial(coefficients, xs[i], prec)
+		diff.Sub(ys[i], diff)
+		residual.Add(residual, diff.Mul(diff, diff))
+
+		diff.Sub(ys[i], mean)
+		total.Add(total, diff.Mul(diff, diff))
+	}
+
+	if total.Sign() == 0 {
+		return new(big.Float).SetPrec(prec).SetInt64(1)
+	}
+
+	residual.Quo(residual, total)
+	return residual.Sub(big.NewFloat(1), residual)
+}
+
+// leastSquares fits a polynomial of the given degree to the data points (xs[i], ys[i])
+// by solving the normal equations with Gaussian elimination.
+func leastSquares(ctx context.Context, degree int, xs, ys []*big.Float, prec uint) ([]*big.Float, error) {
+	// the normal equations are ill-conditioned for higher degrees, hence more guard bits are needed
+	work := prec + uint(degree)*guardBits
+	size := degree + 1
+
+	// power sums Σ x^k for k = 0, ..., 2n and Σ y*x^k for k = 0, ..., n
+	sums, moments := make([]*big.Float, 2*size-1), make([]*big.Float, size)
+	for k := range sums {
+		sums[k] = new(big.Float).SetPrec(work)
+	}
+	for k := range moments {
+		moments[k] = new(big.Float).SetPrec(work)
+	}
+
+	for i := range xs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		power := new(big.Float).SetPrec(work).SetInt64(1)
+		for k := range sums {
+			sums[k].Add(sums[k], power)
+			if k < size {
+				moments[k].Add(moments[k], new(big.Float).SetPrec(work).Mul(ys[i], power))
+			}
+			power.Mul(power, xs[i])
+		}
+	}
+
+	matrix := make([][]*big.Float, size)
+	for i := range matrix {
+		matrix[i] = make([]*big.Float, size)
+		for j := range matrix[i] {
+			matrix[i][j] = new(big.Float).SetPrec(work).Set(sums[i+j])
+		}
+	}
+
+	coefficients, err := gaussianElimination(ctx, matrix, moments, prec)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, coefficient := range coefficients {
+		coefficient.SetPrec(prec)
+	}
+
+	return coefficients, nil
+}
+
+// polynomial evaluates the polynomial with given coefficients in ascending order at x using Horner's method.
+func polynomial(coefficients []*big.Float, x *big.Float, prec uint) *big.Float {
+	result := new(big.Float).SetPrec(prec)
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		result.Mul(result, x)
+		result.Add(result, coefficients[i])
+	}
+
+	return result
+}
+
+// gaussianElimination solves the linear system matrix * x = vector in place using Gaussian elimination with partial pivoting.
+// The system is considered singular if a pivot vanishes relative to the given precision.
+func gaussianElimination(ctx context.Context, matrix [][]*big.Float, vector []*big.Float, prec uint) ([]*big.Float, error) {
+	size := len(vector)
+	scale := make([]int, size) // binary exponent of the largest entry of each row
+	for i := range matrix {
+		scale[i] = math.MinInt
+		for _, value := range matrix[i] {
+			if value.Sign() != 0 && value.MantExp(nil) > scale[i] {
+				scale[i] = value.MantExp(nil)
+			}
+		}
+	}
+
+	for col := 0; col < size; col++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		pivot := col
+		for row := col + 1; row < size; row++ {
+			if new(big.Float).Abs(matrix[row][col]).Cmp(new(big.Float).Abs(matrix[pivot][col])) > 0 {
+				pivot = row
+			}
+		}
+
+		if matrix[pivot][col].Sign() == 0 || matrix[pivot][col].MantExp(nil) < scale[pivot]-int(prec) {
+			return nil, fmt.Errorf("the system of equations is singular")
+		}
+
+		matrix[col], matrix[pivot] = matrix[pivot], matrix[col]
+		vector[col], vector[pivot] = vector[pivot], vector[col]
+		scale[col], scale[pivot] = scale[pivot], scale[col]
+
+		for row := col + 1; row < size; row++ {
+			factor := new(big.Float).SetPrec(matrix[row][col].Prec()).Quo(matrix[row][col], matrix[col][col])
+			for k := col; k < size; k++ {
+				matrix[row][k].Sub(matrix[row][k], new(big.Float).SetPrec(matrix[row][k].Prec()).Mul(factor, matrix[col][k]))
+			}
+			vector[row].Sub(vector[row], new(big.Float).SetPrec(vector[row].Prec()).Mul(factor, vector[col]))
+		}
+	}
+
+	solution := make([]*big.Float, size)
+	for row := size - 1; row >= 0; row-- {
+		sum := new(big.Float).SetPrec(vector[row].Prec()).Set(vector[row])
+		for k := row + 1; k < size; k++ {
+			sum.Sub(sum, new(big.Float).SetPrec(sum.Prec()).Mul(matrix[row][k], solution[k]))
+		}
+		solution[row] = sum.Quo(sum, matrix[row][row])
+	}
+
+	return solution, nil
+}
@@ -0,0 +1,150 @@
+package calc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestFit(t *testing.T) {
+	type args struct {
+		model  Model
+		degree int
+		xs, ys []float64
+	}
+
+	for _, tt := range []struct {
+		name             string
+		args             args
+		wantCoefficients string
+		wantRSquared     string
+		wantResiduals    string
+		wantEquation     string
+	}{
+		{"test#1", args{LinearModel, 0, []float64{1, 2, 3, 4, 5}, []float64{3, 5, 7, 9, 11}},
+			"[1 2]", "1", "[0 0 0 0 0]", "y = 1 + 2·x"},
+		{"test#2", args{LinearModel, 0, []float64{1, 2, 3, 4, 5}, []float64{2.2, 2.8, 3.6, 4.5, 5.1}},
+			"[1.39 0.75]", "0.9952229299", "[0.06 -0.09 -0.04 0.11 -0.04]", "y = 1.39 + 0.75·x"},
+		{"test#3", args{PolynomialModel, 2, []float64{-1, 0, 1, 2, 3}, []float64{6, 3, 2, 3, 6}},
+			"[3 -2 1]", "1", "[0 0 0 0 0]", "y = 3 + -2·x + 1·x^2"},
+		{"test#4", args{ExponentialModel, 0, []float64{1, 2, 3, 4, 5}, []float64{3.1, 4.9, 8.2, 13.8, 22.5}},
+			"[1.845394618 0.4999659782]", "0.9995531068", "", "y = 1.845394618·e^(0.4999659782·x)"},
+		{"test#5", args{LogarithmicModel, 0, []float64{1, 2, 3, 4, 5}, []float64{2.2, 2.8, 3.6, 4.5, 5.1}},
+			"[1.911806307 1.804905142]", "0.9311306295", "", "y = 1.911806307 + 1.804905142·ln(x)"},
+		{"test#6", args{PowerModel, 0, []float64{1, 2, 3, 4}, []float64{2, 16, 54, 128}},
+			"[2 3]", "1", "[0 0 0 0]", "y = 2·x^3"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			xs, ys := floats(tt.args.xs...), floats(tt.args.ys...)
+			got, err := Fit(context.TODO(), tt.args.model, tt.args.degree, xs, ys)
+			if err != nil {
+				t.Fatalf("Error fitting %s model: %v", tt.args.model, err)
+			}
+
+			if text := rounded(got.Coefficients); text != tt.wantCoefficients {
+				t.Errorf("Fit().Coefficients = %s, want %s", text, tt.wantCoefficients)
+			}
+
+			if text := rounded([]*big.Float{got.RSquared}); text != "["+tt.wantRSquared+"]" {
+				t.Errorf("Fit().RSquared = %s, want %s", text, tt.wantRSquared)
+			}
+
+			if text := rounded(got.Residuals); tt.wantResiduals != "" && text != tt.wantResiduals {
+				t.Errorf("Fit().Residuals = %s, want %s", text, tt.wantResiduals)
+			}
+
+			if equation := got.String(); equation != tt.wantEquation {
+				t.Errorf("Fit().String() = %s, want %s", equation, tt.wantEquation)
+			}
+		})
+	}
+}
+
+func TestFitErrors(t *testing.T) {
+	type args struct {
+		model  Model
+		degree int
+		xs, ys []float64
+	}
+
+	for _, tt := range []struct {
+		name          string
+		args          args
+		wantErr       string
+		wantErrDomain bool
+	}{
+		{"test#1", args{"cubic", 0, []float64{1, 2}, []float64{1, 2}}, "unsupported regression model", false},
+		{"test#2", args{LinearModel, 0, []float64{1, 2}, []float64{1}}, "same number of x and y values", false},
+		{"test#3", args{PolynomialModel, 3, []float64{1, 2, 3}, []float64{1, 2, 3}}, "at least 4 data points", false},
+		{"test#4", args{PolynomialModel, 0, []float64{1, 2, 3}, []float64{1, 2, 3}}, "degree of at least 1", false},
+		{"test#5", args{LinearModel, 0, []float64{0.1, 0.1, 0.1}, []float64{1, 2, 3}}, "singular", false},
+		{"test#6", args{ExponentialModel, 0, []float64{1, 2, 3}, []float64{1, 0, 3}}, "positive y values", true},
+		{"test#7", args{LogarithmicModel, 0, []float64{-1, 2, 3}, []float64{1, 2, 3}}, "positive x values", true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Fit(context.TODO(), tt.args.model, tt.args.degree, floats(tt.args.xs...), floats(tt.args.ys...))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Fit() error = %v, want %q", err, tt.wantErr)
+			}
+
+			if errors.Is(err, ErrDomain) != tt.wantErrDomain {
+				t.Errorf("Fit() error = %v, want domain error: %t", err, tt.wantErrDomain)
+			}
+		})
+	}
+}
+
+func TestRegressionPredict(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		model Model
+		xs    []float64
+		ys    []float64
+		arg   float64
+		want  string
+	}{
+		{"test#1", LinearModel, []float64{1, 2, 3}, []float64{3, 5, 7}, 4.5, "10"},
+		{"test#2", ExponentialModel, []float64{1, 2, 3, 4, 5}, []float64{3.1, 4.9, 8.2, 13.8, 22.5}, 4.5, "17.50593632"},
+		{"test#3", PowerModel, []float64{1, 2, 3, 4}, []float64{2, 16, 54, 128}, 0.5, "0.25"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			fit, err := Fit(context.TODO(), tt.model, 1, floats(tt.xs...), floats(tt.ys...))
+			if err != nil {
+				t.Fatalf("Error fitting %s model: %v", tt.model, err)
+			}
+
+			if got, err := fit.Predict(context.TODO(), big.NewFloat(tt.arg)); err != nil {
+				t.Errorf("Error predicting at %g: %v", tt.arg, err)
+			} else if text := got.Text('g', 10); text != tt.want {
+				t.Errorf("Predict(%g) = %s, want %s", tt.arg, text, tt.want)
+			}
+		})
+	}
+}
+
+// floats converts float64 values to big.Float values.
+func floats(values ...float64) []*big.Float {
+	result := make([]*big.Float, len(values))
+	for i, value := range values {
+		result[i] = big.NewFloat(value)
+	}
+
+	return result
+}
+
+// rounded formats a list of numbers rounded to 10 significant digits for comparison in tests.
+// Values which vanish relative to the precision are formatted as 0.
+func rounded(floats []*big.Float) string {
+	texts := make([]string, len(floats))
+	for i, f := range floats {
+		if f.Sign() == 0 || f.MantExp(nil) < -40 {
+			texts[i] = "0"
+		} else {
+			texts[i] = f.Text('g', 10)
+		}
+	}
+
+	return "[" + strings.Join(texts, " ") + "]"
+}
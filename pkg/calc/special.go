@@ -0,0 +1,488 @@
+package calc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// ErrDomain is returned if an argument is outside of the domain of a function.
+var ErrDomain = errors.New("domain error")
+
+// Atan calculates the arc tangent of x at the precision of x.
+// The argument is reduced using atan(x) = π/2 - atan(1/x) and atan(x) = 2 * atan(x / (1 + sqrt(1 + x^2)))
+// before the Taylor series atan(x) = x - x^3/3 + x^5/5 - ... is evaluated.
+func Atan(ctx context.Context, x *big.Float) (*big.Float, error) {
+	prec := precision(x)
+	result, err := atan(ctx, x, prec+guardBits)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.SetPrec(prec), nil
+}
+
+// Erf calculates the error function erf(x) = 2/sqrt(π) * ∫[0, x] exp(-t^2) dt at the precision of x.
+func Erf(ctx context.Context, x *big.Float) (*big.Float, error) {
+	prec := precision(x)
+	result, err := erf(ctx, x, prec+guardBits)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.SetPrec(prec), nil
+}
+
+// Erfc calculates the complementary error function erfc(x) = 1 - erf(x) at the precision of x.
+// Unlike 1 - Erf(x), the result retains its relative precision for large x.
+func Erfc(ctx context.Context, x *big.Float) (*big.Float, error) {
+	prec := precision(x)
+	result, err := erfc(ctx, x, prec+guardBits)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.SetPrec(prec), nil
+}
+
+// Exp calculates the exponential function e^x at the precision of x.
+// The argument is reduced using e^x = 2^k * e^r with r = x - k*ln(2)
+// and e^r = (e^(r/2^s))^(2^s) before the Taylor series is evaluated.
+func Exp(ctx context.Context, x *big.Float) (*big.Float, error) {
+	prec := precision(x)
+	result, err := exp(ctx, x, prec+guardBits)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.SetPrec(prec), nil
+}
+
+// Ln calculates the natural logarithm of x at the precision of x.
+// The argument is reduced using ln(x) = ln(m) + k*ln(2) with x = m * 2^k and sqrt(2)/2 <= m < sqrt(2)
+// before the series ln(m) = 2 * atanh((m-1) / (m+1)) is evaluated.
+func Ln(ctx context.Context, x *big.Float) (*big.Float, error) {
+	prec := precision(x)
+	result, err := ln(ctx, x, prec+guardBits)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.SetPrec(prec), nil
+}
+
+// Pi calculates π with the given precision in bits using Machin's formula π = 16*atan(1/5) - 4*atan(1/239)
+func Pi(ctx context.Context, prec uint) (*big.Float, error) {
+	result, err := pi(ctx, prec+guardBits)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.SetPrec(prec), nil
+}
+
+// atan calculates the arc tangent of x with the given precision.
+func atan(ctx context.Context, x *big.Float, prec uint) (*big.Float, error) {
+	if x.IsInf() {
+		result, err := pi(ctx, prec)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Quo(result, big.NewFloat(2))
+		if x.Sign() < 0 {
+			result.Neg(result)
+		}
+
+		return result, nil
+	}
+
+	one := big.NewFloat(1)
+	abs := new(big.Float).SetPrec(prec).Abs(x)
+	if abs.Cmp(one) > 0 { // atan(x) = sign(x) * π/2 - atan(1/x)
+		result, err := pi(ctx, prec)
+		if err != nil {
+			return nil, err
+		}
+
+		inverse, err := atan(ctx, abs.Quo(one, abs), prec)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Quo(result, big.NewFloat(2)).Sub(result, inverse)
+		if x.Sign() < 0 {
+			result.Neg(result)
+		}
+
+		return result, nil
+	}
+
+	// halve the argument until it is small enough for the series to converge quickly
+	reduced, doublings := new(big.Float).SetPrec(prec).Set(x), 0
+	for ; reduced.Sign() != 0 && reduced.MantExp(nil) > -8; doublings++ {
+		denominator := new(big.Float).SetPrec(prec).Mul(reduced, reduced)
+		denominator.Add(denominator, one).Sqrt(denominator).Add(denominator, one)
+		reduced.Quo(reduced, denominator)
+	}
+
+	result, err := atanSeries(ctx, reduced, prec)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.SetMantExp(result, doublings), nil
+}
+
+// atanSeries evaluates the Taylor series of the arc tangent for small |x| with the given precision.
+func atanSeries(ctx context.Context, x *big.Float, prec uint) (*big.Float, error) {
+	result := new(big.Float).SetPrec(prec).Set(x)
+	power := new(big.Float).SetPrec(prec).Set(x)
+	square := new(big.Float).SetPrec(prec).Mul(x, x)
+	term := new(big.Float).SetPrec(prec)
+
+	for n := int64(1); power.Sign() != 0; n++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		power.Mul(power, square).Neg(power)
+		term.Quo(power, new(big.Float).SetInt64(2*n+1))
+		if converged(result, term, prec) {
+			break
+		}
+
+		result.Add(result, term)
+	}
+
+	return result, nil
+}
+
+// converged reports whether adding term to sum does not change sum at the given precision.
+func converged(sum, term *big.Float, prec uint) bool {
+	if term.Sign() == 0 {
+		return true
+	}
+
+	if sum.Sign() == 0 {
+		return false
+	}
+
+	return term.MantExp(nil) < sum.MantExp(nil)-int(prec)-1
+}
+
+// erf calculates the error function with the given precision.
+// For small |x| the Maclaurin series erf(x) = 2/sqrt(π) * Σ (-1)^n x^(2n+1) / (n! * (2n+1)) is used,
+// for large |x| erf(x) = 1 - erfc(x) with erfc evaluated by a continued fraction.
+func erf(ctx context.Context, x *big.Float, prec uint) (*big.Float, error) {
+	if x.IsInf() {
+		return big.NewFloat(float64(x.Sign())), nil
+	}
+
+	if !useErfcFraction(x, prec) {
+		return erfSeries(ctx, x, prec)
+	}
+
+	abs := new(big.Float).SetPrec(prec).Abs(x)
+	result, err := erfcFraction(ctx, abs, prec)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Sub(big.NewFloat(1), result)
+	if x.Sign() < 0 {
+		result.Neg(result)
+	}
+
+	return result, nil
+}
+
+// erfSeries evaluates the Maclaurin series of the error function.
+// The terms grow up to e^(x^2) before they decay, hence additional guard bits are used.
+func erfSeries(ctx context.Context, x *big.Float, prec uint) (*big.Float, error) {
+	square, _ := new(big.Float).Mul(x, x).Float64()
+	work := prec + uint(2*square*math.Log2E) + guardBits
+
+	result := new(big.Float).SetPrec(work).Set(x)
+	power := new(big.Float).SetPrec(work).Set(x)
+	squareX := new(big.Float).SetPrec(work).Mul(x, x)
+	term := new(big.Float).SetPrec(work)
+
+	for n := int64(1); power.Sign() != 0; n++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// power = (-1)^n x^(2n+1) / n!
+		power.Mul(power, squareX).Neg(power).Quo(power, new(big.Float).SetInt64(n))
+		term.Quo(power, new(big.Float).SetInt64(2*n+1))
+		if converged(result, term, work) {
+			break
+		}
+
+		result.Add(result, term)
+	}
+
+	sqrtPi, err := pi(ctx, work)
+	if err != nil {
+		return nil, err
+	}
+	sqrtPi.Sqrt(sqrtPi)
+
+	result.Mul(result, big.NewFloat(2)).Quo(result, sqrtPi)
+
+	return result.SetPrec(prec), nil
+}
+
+// erfc calculates the complementary error function with the given precision.
+func erfc(ctx context.Context, x *big.Float, prec uint) (*big.Float, error) {
+	switch {
+	case x.IsInf():
+		return big.NewFloat(float64(1 - x.Sign())), nil
+
+	case x.Sign() > 0 && useErfcFraction(x, prec):
+		return erfcFraction(ctx, x, prec)
+
+	}
+
+	// erfc(x) = 1 - erf(x) loses the relative precision of the result for x > 0,
+	// hence the number of lost bits, -log2(erfc(x)) ≈ x^2 * log2(e), is added to the precision
+	work := prec
+	if x.Sign() > 0 {
+		square, _ := new(big.Float).Mul(x, x).Float64()
+		work += uint(square * math.Log2E)
+	}
+
+	result, err := erf(ctx, x, work)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Sub(big.NewFloat(1), result).SetPrec(prec), nil
+}
+
+// erfcFraction evaluates the continued fraction of the complementary error function for x > 0
+// erfc(x) = exp(-x^2)/sqrt(π) * 1/(x + (1/2)/(x + 1/(x + (3/2)/(x + 2/(x + ...)))))
+// using the modified Lentz algorithm.
+func erfcFraction(ctx context.Context, x *big.Float, prec uint) (*big.Float, error) {
+	tiny := new(big.Float).SetMantExp(big.NewFloat(1), -int(2*prec))
+	one := big.NewFloat(1)
+
+	// f = b0 + a1/(b1 + a2/(b2 + ...)) with b_n = x and a_n = n/2
+	f := new(big.Float).SetPrec(prec).Set(x)
+	c := new(big.Float).SetPrec(prec).Set(f)
+	d := new(big.Float).SetPrec(prec)
+	delta := new(big.Float).SetPrec(prec)
+
+	for n := int64(1); ; n++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		a := new(big.Float).SetPrec(prec).Quo(new(big.Float).SetInt64(n), big.NewFloat(2))
+
+		// d = 1 / (b + a*d)
+		d.Mul(a, d).Add(d, x)
+		if d.Sign() == 0 {
+			d.Set(tiny)
+		}
+		d.Quo(one, d)
+
+		// c = b + a/c
+		c.Quo(a, c).Add(c, x)
+		if c.Sign() == 0 {
+			c.Set(tiny)
+		}
+
+		delta.Mul(c, d)
+		f.Mul(f, delta)
+
+		if converged(one, delta.Sub(delta, one), prec) {
+			break
+		}
+	}
+
+	// erfc(x) = exp(-x^2) / (sqrt(π) * f)
+	exponent := new(big.Float).SetPrec(prec).Mul(x, x)
+	result, err := exp(ctx, exponent.Neg(exponent), prec)
+	if err != nil {
+		return nil, err
+	}
+
+	sqrtPi, err := pi(ctx, prec)
+	if err != nil {
+		return nil, err
+	}
+	sqrtPi.Sqrt(sqrtPi)
+
+	return result.Quo(result, sqrtPi.Mul(sqrtPi, f)), nil
+}
+
+// exp calculates the exponential function with the given precision.
+func exp(ctx context.Context, x *big.Float, prec uint) (*big.Float, error) {
+	switch {
+	case x.IsInf() && x.Sign() > 0:
+		return new(big.Float).SetInf(false), nil
+
+	case x.IsInf():
+		return big.NewFloat(0), nil
+
+	case x.Sign() == 0:
+		return new(big.Float).SetPrec(prec).SetInt64(1), nil
+
+	}
+
+	// estimate k = round(x / ln(2)) using float64 arithmetic
+	estimate, _ := x.Float64()
+	k := math.Round(estimate / math.Ln2)
+	switch {
+	case k < math.MinInt32/2: // the result is too small to be represented, i.e., it underflows to 0
+		return big.NewFloat(0), nil
+
+	case k > math.MaxInt32/2:
+		return nil, fmt.Errorf("%w: exponent %s is out of range", ErrDomain, x.Text('g', 10))
+
+	}
+
+	// r = x - k*ln(2), where ln(2) needs additional bits to compensate for the magnitude of k
+	work := prec + uint(math.Log2(math.Abs(k)+1)) + 1
+	ln2, err := ln2(ctx, work)
+	if err != nil {
+		return nil, err
+	}
+
+	r := new(big.Float).SetPrec(work).Mul(ln2, new(big.Float).SetInt64(int64(k)))
+	r.Sub(x, r)
+
+	// r = r / 2^s such that the series converges quickly
+	s := int(math.Sqrt(float64(prec))) + 1
+	r.SetMantExp(r, -s)
+
+	// e^r = 1 + r + r^2/2! + r^3/3! + ...
+	result := new(big.Float).SetPrec(work).SetInt64(1)
+	term := new(big.Float).SetPrec(work).SetInt64(1)
+	for n := int64(1); ; n++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		term.Mul(term, r).Quo(term, new(big.Float).SetInt64(n))
+		if converged(result, term, work) {
+			break
+		}
+
+		result.Add(result, term)
+	}
+
+	// e^(r*2^s) = (e^r)^(2^s)
+	for i := 0; i < s; i++ {
+		result.Mul(result, result)
+	}
+
+	return result.SetMantExp(result, int(k)).SetPrec(prec), nil
+}
+
+// ln calculates the natural logarithm of x with the given precision.
+func ln(ctx context.Context, x *big.Float, prec uint) (*big.Float, error) {
+	switch {
+	case x.Sign() <= 0:
+		return nil, fmt.Errorf("%w: logarithm of %s is undefined", ErrDomain, x.Text('g', 10))
+
+	case x.IsInf():
+		return new(big.Float).SetInf(false), nil
+
+	}
+
+	work := prec + guardBits
+	m := new(big.Float).SetPrec(work)
+	k := x.MantExp(m)
+
+	// shift m into [sqrt(2)/2, sqrt(2)) so that ln(x) retains its relative precision for x close to 1
+	if m.Cmp(big.NewFloat(math.Sqrt2/2)) < 0 {
+		m.SetMantExp(m, 1)
+		k--
+	}
+
+	// z = (m-1) / (m+1), where |z| < 0.18
+	z := new(big.Float).SetPrec(work).Sub(m, big.NewFloat(1))
+	z.Quo(z, m.Add(m, big.NewFloat(1)))
+	square := new(big.Float).SetPrec(work).Mul(z, z)
+
+	// atanh(z) = z + z^3/3 + z^5/5 + ...
+	result := new(big.Float).SetPrec(work).Set(z)
+	power := new(big.Float).SetPrec(work).Set(z)
+	term := new(big.Float).SetPrec(work)
+	for n := int64(3); ; n += 2 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		power.Mul(power, square)
+		term.Quo(power, new(big.Float).SetInt64(n))
+		if converged(result, term, work) {
+			break
+		}
+
+		result.Add(result, term)
+	}
+	result.SetMantExp(result, 1)
+
+	if k != 0 {
+		ln2, err := ln2(ctx, work)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Add(result, ln2.Mul(ln2, new(big.Float).SetInt64(int64(k))))
+	}
+
+	return result.SetPrec(prec), nil
+}
+
+// ln2 calculates the natural logarithm of 2 with the given precision using the series ln(2) = Σ 1 / (k * 2^k)
+func ln2(ctx context.Context, prec uint) (*big.Float, error) {
+	work := prec + guardBits
+	result := new(big.Float).SetPrec(work)
+	term := new(big.Float).SetPrec(work)
+
+	for k := 1; k < int(work)+2; k++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		term.SetInt64(int64(k))
+		term.SetMantExp(term.Quo(big.NewFloat(1), term), -k)
+		result.Add(result, term)
+	}
+
+	return result.SetPrec(prec), nil
+}
+
+// pi calculates π with the given precision using Machin's formula.
+func pi(ctx context.Context, prec uint) (*big.Float, error) {
+	work := prec + guardBits
+
+	a, err := atanSeries(ctx, new(big.Float).SetPrec(work).Quo(big.NewFloat(1), big.NewFloat(5)), work)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := atanSeries(ctx, new(big.Float).SetPrec(work).Quo(big.NewFloat(1), big.NewFloat(239)), work)
+	if err != nil {
+		return nil, err
+	}
+
+	a.Mul(a, big.NewFloat(16))
+	b.Mul(b, big.NewFloat(4))
+
+	return a.Sub(a, b).SetPrec(prec), nil
+}
+
+// useErfcFraction reports whether the continued fraction of erfc converges quickly enough for x,
+// which is the case if x^2 exceeds a fraction of the precision.
+func useErfcFraction(x *big.Float, prec uint) bool {
+	square, _ := new(big.Float).Mul(x, x).Float64()
+	return square > float64(prec)/4
+}
@@ -0,0 +1,48 @@
+package calc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+)
+
+func TestSpecialFunctions(t *testing.T) {
+	const prec = 200
+
+	arg := func(s string) *big.Float {
+		f, _ := new(big.Float).SetPrec(prec).SetString(s)
+		return f
+	}
+
+	for _, tt := range []struct {
+		name string
+		expr string
+		fn   func() (*big.Float, error)
+		want string
+	}{
+		{"test#1", "atan(1)*4", func() (*big.Float, error) {
+			r, err := Atan(context.TODO(), arg("1"))
+			if err != nil {
+				return nil, err
+			}
+			return r.Mul(r, big.NewFloat(4)), nil
+		}, "3.14159265358979323846264338327950288419716939937510582097494"},
+		{"test#2", "atan(-3)", func() (*big.Float, error) { return Atan(context.TODO(), arg("-3")) }, "-1.24904577239825442582991707728109012307782940412989671905467"},
+		{"test#3", "erf(1)", func() (*big.Float, error) { return Erf(context.TODO(), arg("1")) }, "0.842700792949714869341220635082609259296066997966302908459938"},
+		{"test#4", "erf(-0.5)", func() (*big.Float, error) { return Erf(context.TODO(), arg("-0.5")) }, "-0.520499877813046537682746653891964528736451575757963700058806"},
+		{"test#5", "erfc(10)", func() (*big.Float, error) { return Erfc(context.TODO(), arg("10")) }, "2.08848758376254475700078629495778861156081811932116372701221e-45"},
+		{"test#6", "exp(1)", func() (*big.Float, error) { return Exp(context.TODO(), arg("1")) }, "2.71828182845904523536028747135266249775724709369995957496697"},
+		{"test#7", "exp(-100)", func() (*big.Float, error) { return Exp(context.TODO(), arg("-100")) }, "3.72007597602083596295969580386311833735889229237678196712061e-44"},
+		{"test#8", "ln(10)", func() (*big.Float, error) { return Ln(context.TODO(), arg("10")) }, "2.30258509299404568401799145468436420760110148862877297603333"},
+		{"test#9", "ln(1+2^-20)", func() (*big.Float, error) { return Ln(context.TODO(), arg("1.00000095367431640625")) }, "9.53673861659188233908415514963336143603148070979302858112255e-07"},
+		{"test#10", "pi", func() (*big.Float, error) { return Pi(context.TODO(), prec) }, "3.14159265358979323846264338327950288419716939937510582097494"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := tt.fn(); err != nil {
+				t.Errorf("Error calculating %s: %v", tt.expr, err)
+			} else if text := got.Text('g', 60); text != tt.want {
+				t.Errorf("%s = %s, want %s", tt.expr, text, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,367 @@
+package calc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"sort"
+)
+
+// guardBits is the number of additional bits used for intermediate results
+// to prevent the accumulation of rounding errors.
+const guardBits = 64
+
+// maxExactBits is the largest precision used to add values without rounding,
+// since the precision grows with the ratio of the largest to the smallest value.
+const maxExactBits = 1 << 20
+
+// Statistics holds the summary statistics of a data list.
+// Sample statistics are nil if the data list contains less than two values.
+type Statistics struct {
+	Count                       *big.Float
+	Sum                         *big.Float
+	Mean                        *big.Float
+	Median                      *big.Float
+	Mode                        *big.Float
+	Minimum                     *big.Float
+	Maximum                     *big.Float
+	Range                       *big.Float
+	LowerQuartile               *big.Float
+	UpperQuartile               *big.Float
+	PopulationVariance          *big.Float
+	PopulationStandardDeviation *big.Float
+	SampleVariance              *big.Float
+	SampleStandardDeviation     *big.Float
+}
+
+// Describe calculates all summary statistics of args at once.
+// Undefined statistics, which math/big signals by panicking with big.ErrNaN, e.g., the sum of Inf and -Inf,
+// are returned as errors wrapping ErrDomain.
+func Describe(ctx context.Context, args ...*big.Float) (stats *Statistics, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			nan, ok := r.(big.ErrNaN)
+			if !ok {
+				panic(r)
+			}
+
+			stats, err = nil, fmt.Errorf("%w: %s", ErrDomain, nan.Error())
+		}
+	}()
+
+	stats = &Statistics{Count: big.NewFloat(float64(len(args)))}
+
+	for _, step := range []struct {
+		target *(*big.Float)
+		fn     func(context.Context, ...*big.Float) (*big.Float, error)
+	}{
+		{&stats.Sum, Sum},
+		{&stats.Mean, Mean},
+		{&stats.Median, Median},
+		{&stats.Mode, Mode},
+		{&stats.Minimum, Minimum},
+		{&stats.Maximum, Maximum},
+		{&stats.Range, Range},
+		{&stats.PopulationVariance, PopulationVariance},
+		{&stats.PopulationStandardDeviation, PopulationStandardDeviation},
+	} {
+		if *step.target, err = step.fn(ctx, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	if stats.LowerQuartile, err = Quantile(ctx, big.NewFloat(0.25), args...); err != nil {
+		return nil, err
+	}
+
+	if stats.UpperQuartile, err = Quantile(ctx, big.NewFloat(0.75), args...); err != nil {
+		return nil, err
+	}
+
+	if len(args) < 2 {
+		return stats, nil
+	}
+
+	if stats.SampleVariance, err = SampleVariance(ctx, args...); err != nil {
+		return nil, err
+	}
+
+	if stats.SampleStandardDeviation, err = SampleStandardDeviation(ctx, args...); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// Maximum calculates the largest value of args.
+func Maximum(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+	values, err := sorted(ctx, "max", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return values[len(values)-1], nil
+}
+
+// Mean calculates the arithmetic mean of args, i.e., sum(x) / n
+func Mean(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("mean function requires at least 1 argument")
+	}
+
+	sum, err := sum(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Float).SetPrec(precision(args...)).Quo(sum, big.NewFloat(float64(len(args)))), nil
+}
+
+// Median calculates the median of args.
+// For an even number of values, the median is the mean of the two middle values.
+func Median(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+	return Quantile(ctx, big.NewFloat(0.5), args...)
+}
+
+// Minimum calculates the smallest value of args.
+func Minimum(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+	values, err := sorted(ctx, "min", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return values[0], nil
+}
+
+// Mode calculates the most frequent value of args.
+// If there are several values with the same frequency, the smallest of them is returned.
+func Mode(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+	values, err := sorted(ctx, "mode", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	mode, frequency := values[0], 0
+	for i := 0; i < len(values); {
+		j := i
+		for j < len(values) && values[j].Cmp(values[i]) == 0 {
+			j++
+		}
+
+		if j-i > frequency {
+			mode, frequency = values[i], j-i
+		}
+
+		i = j
+	}
+
+	return mode, nil
+}
+
+// PopulationStandardDeviation calculates the standard deviation of args treated as the whole population,
+// i.e., sqrt(sum((x - mean)^2) / n)
+func PopulationStandardDeviation(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+	return deviation(ctx, "stdevp", 0, args...)
+}
+
+// PopulationVariance calculates the variance of args treated as the whole population,
+// i.e., sum((x - mean)^2) / n
+func PopulationVariance(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+	return variance(ctx, "varp", 0, precision(args...), args...)
+}
+
+// Quantile calculates the p-quantile of args using linear interpolation between the closest ranks,
+// i.e., for h = (n-1)*p: Q(p) = x[floor(h)] + (h - floor(h)) * (x[floor(h)+1] - x[floor(h)])
+func Quantile(ctx context.Context, p *big.Float, args ...*big.Float) (*big.Float, error) {
+	if p.Sign() < 0 || p.Cmp(big.NewFloat(1)) > 0 {
+		return nil, fmt.Errorf("quantile is only defined for probabilities between 0 and 1")
+	}
+
+	values, err := sorted(ctx, "quantile", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// the interpolation is carried out without rounding, only the result is rounded
+	h := product(p, big.NewFloat(float64(len(values)-1)))
+	lower, _ := h.Int64()
+	if lower >= int64(len(values)-1) {
+		return values[len(values)-1], nil
+	}
+
+	fraction := difference(h, big.NewFloat(float64(lower)))
+	if fraction.Sign() == 0 {
+		return values[lower], nil
+	}
+
+	result := product(difference(values[lower+1], values[lower]), fraction)
+	return new(big.Float).SetPrec(precision(args...)).Add(result, values[lower]), nil
+}
+
+// Range calculates the difference between the largest and the smallest value of args.
+func Range(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+	values, err := sorted(ctx, "range", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Float).SetPrec(precision(args...)).Sub(values[len(values)-1], values[0]), nil
+}
+
+// SampleStandardDeviation calculates the standard deviation of args treated as a sample of a population,
+// i.e., sqrt(sum((x - mean)^2) / (n - 1))
+func SampleStandardDeviation(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+	return deviation(ctx, "stdev", 1, args...)
+}
+
+// SampleVariance calculates the variance of args treated as a sample of a population,
+// i.e., sum((x - mean)^2) / (n - 1)
+func SampleVariance(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+	return variance(ctx, "var", 1, precision(args...), args...)
+}
+
+// Sum calculates the sum of args.
+// The sum of no arguments is 0.
+func Sum(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+	result, err := sum(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.SetPrec(precision(args...)), nil
+}
+
+// deviation calculates the standard deviation of args with the given delta degrees of freedom.
+func deviation(ctx context.Context, name string, ddof int, args ...*big.Float) (*big.Float, error) {
+	prec := precision(args...)
+	result, err := variance(ctx, name, ddof, prec+guardBits, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Sqrt(result).SetPrec(prec), nil
+}
+
+// difference calculates a - b without rounding, unless the precision needed exceeds maxExactBits.
+func difference(a, b *big.Float) *big.Float {
+	return new(big.Float).SetPrec(exactPrecision(max(a.Prec(), b.Prec()), a, b)).Sub(a, b)
+}
+
+// exactPrecision returns the precision needed to add args without rounding, which is at least prec.
+// If it exceeds maxExactBits, prec is returned.
+func exactPrecision(prec uint, args ...*big.Float) uint {
+	var high, low int
+	found := false
+	for _, arg := range args {
+		if arg.Sign() == 0 || arg.IsInf() {
+			continue
+		}
+
+		// the most significant bit of arg is 2^(exp-1) and the least significant bit is 2^(exp-MinPrec)
+		exp := arg.MantExp(nil)
+		if !found || exp > high {
+			high = exp
+		}
+
+		if lsb := exp - int(arg.MinPrec()); !found || lsb < low {
+			low = lsb
+		}
+
+		found = true
+	}
+
+	// the carries of the additions need up to bits.Len(n) additional bits
+	needed := high - low + bits.Len(uint(len(args)))
+	if !found || needed <= int(prec) || needed > maxExactBits {
+		return prec
+	}
+
+	return uint(needed)
+}
+
+// precision returns the working precision for the given arguments,
+// i.e., the largest precision of all arguments.
+func precision(args ...*big.Float) uint {
+	prec := uint(53) // precision of float64
+	for _, arg := range args {
+		if arg.Prec() > prec {
+			prec = arg.Prec()
+		}
+	}
+
+	return prec
+}
+
+// product calculates a * b without rounding.
+func product(a, b *big.Float) *big.Float {
+	return new(big.Float).SetPrec(a.MinPrec()+b.MinPrec()).Mul(a, b)
+}
+
+// sorted returns a sorted copy of args.
+func sorted(ctx context.Context, name string, args ...*big.Float) ([]*big.Float, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%s function requires at least 1 argument", name)
+	}
+
+	values := make([]*big.Float, len(args))
+	_ = copy(values, args)
+	sort.Slice(values, func(i, j int) bool { return values[i].Cmp(values[j]) < 0 })
+
+	return values, nil
+}
+
+// sum calculates the sum of args without rounding, see exactPrecision, but at least with additional guard bits.
+func sum(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+	result := new(big.Float).SetPrec(exactPrecision(precision(args...)+guardBits, args...))
+	for _, arg := range args {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		result.Add(result, arg)
+	}
+
+	return result, nil
+}
+
+// variance calculates the variance of args with the given delta degrees of freedom rounded to prec,
+// i.e., sum((x - mean)^2) / (n - ddof), using the two-pass algorithm.
+// The deviations are scaled by n to calculate the sum of their squares without rounding,
+// i.e., sum((n*x - sum(x))^2) / (n^2 * (n - ddof)), so that only the result is rounded.
+func variance(ctx context.Context, name string, ddof int, prec uint, args ...*big.Float) (*big.Float, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%s function requires at least 1 argument", name)
+	}
+
+	if len(args) <= ddof {
+		return nil, fmt.Errorf("%s function requires at least %d arguments", name, ddof+1)
+	}
+
+	total, err := sum(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	n := big.NewFloat(float64(len(args)))
+	squares := make([]*big.Float, len(args))
+	for i, arg := range args {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		scaled := difference(product(n, arg), total)
+		squares[i] = product(scaled, scaled)
+	}
+
+	result, err := sum(ctx, squares...)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Float).SetPrec(prec).Quo(result, product(product(n, n), big.NewFloat(float64(len(args)-ddof)))), nil
+}
@@ -0,0 +1,153 @@
+package calc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	floats := func(values ...float64) (out []*big.Float) {
+		for _, v := range values {
+			out = append(out, big.NewFloat(v))
+		}
+		return
+	}
+
+	for _, tt := range []struct {
+		name string
+		args []*big.Float
+		want map[string]string
+	}{
+		{"test#1", floats(2, 4, 4, 4, 5, 5, 7, 9), map[string]string{
+			"count": "8", "sum": "40", "mean": "5", "median": "4.5", "mode": "4",
+			"min": "2", "max": "9", "range": "7", "q1": "4", "q3": "5.5",
+			"varp": "4", "stdevp": "2", "var": "4.571428571", "stdev": "2.138089935",
+		}},
+		{"test#2", floats(3, 1, 2), map[string]string{
+			"count": "3", "sum": "6", "mean": "2", "median": "2", "mode": "1",
+			"min": "1", "max": "3", "range": "2", "q1": "1.5", "q3": "2.5",
+			"varp": "0.6666666667", "stdevp": "0.8164965809", "var": "1", "stdev": "1",
+		}},
+		{"test#3", floats(42), map[string]string{
+			"count": "1", "sum": "42", "mean": "42", "median": "42", "mode": "42",
+			"min": "42", "max": "42", "range": "0", "q1": "42", "q3": "42",
+			"varp": "0", "stdevp": "0", "var": "<nil>", "stdev": "<nil>",
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := Describe(context.TODO(), tt.args...)
+			if err != nil {
+				t.Fatalf("Error describing %v: %v", tt.args, err)
+			}
+
+			for key, got := range map[string]*big.Float{
+				"count": stats.Count, "sum": stats.Sum, "mean": stats.Mean, "median": stats.Median, "mode": stats.Mode,
+				"min": stats.Minimum, "max": stats.Maximum, "range": stats.Range, "q1": stats.LowerQuartile, "q3": stats.UpperQuartile,
+				"varp": stats.PopulationVariance, "stdevp": stats.PopulationStandardDeviation,
+				"var": stats.SampleVariance, "stdev": stats.SampleStandardDeviation,
+			} {
+				if text := fmt.Sprintf("%.10g", got); text != tt.want[key] {
+					t.Errorf("Describe(%v).%s = %s, want %s", tt.args, key, text, tt.want[key])
+				}
+			}
+		})
+	}
+}
+
+func TestStatisticsPrecision(t *testing.T) {
+	// 1e20 + 1 cannot be represented with the precision of float64
+	large, _ := new(big.Float).SetPrec(128).SetString("100000000000000000001")
+	small := new(big.Float).SetPrec(128).SetInt64(-1)
+
+	if got, err := Sum(context.TODO(), large, small, small); err != nil {
+		t.Errorf("Error calculating sum: %v", err)
+	} else if got.Text('f', -1) != "99999999999999999999" {
+		t.Errorf("Sum() = %s, want 99999999999999999999", got.Text('f', -1))
+	}
+
+	if got, err := Mean(context.TODO(), large, small, small); err != nil {
+		t.Errorf("Error calculating mean: %v", err)
+	} else if got.Text('f', -1) != "33333333333333333333" {
+		t.Errorf("Mean() = %s, want 33333333333333333333", got.Text('f', -1))
+	}
+}
+
+func TestStatisticsAccuracy(t *testing.T) {
+	// 2^200 + 1 cannot be represented with the precision of float64
+	large := new(big.Float).SetMantExp(big.NewFloat(1), 200)
+	ctx := context.TODO()
+
+	for _, tt := range []struct {
+		name  string
+		fn    func() (*big.Float, error)
+		want  string
+		exact bool
+	}{
+		{"test#1", func() (*big.Float, error) { return Sum(ctx, floats(1, 2)...) }, "3", true},
+		{"test#2", func() (*big.Float, error) { return Sum(ctx, large, big.NewFloat(1), big.NewFloat(1)) }, "1.606938044e+60", false},
+		{"test#3", func() (*big.Float, error) { return Mean(ctx, floats(1, 2)...) }, "1.5", true},
+		{"test#4", func() (*big.Float, error) { return Mean(ctx, floats(1, 2, 2)...) }, "1.666666667", false},
+		{"test#5", func() (*big.Float, error) { return Median(ctx, floats(1, 2, 4, 8)...) }, "3", true},
+		{"test#6", func() (*big.Float, error) { return Median(ctx, big.NewFloat(1), large) }, "8.034690221e+59", false},
+		{"test#7", func() (*big.Float, error) { return SampleVariance(ctx, floats(1, 2, 3)...) }, "1", true},
+		{"test#8", func() (*big.Float, error) { return PopulationVariance(ctx, floats(1, 2, 3)...) }, "0.6666666667", false},
+		{"test#9", func() (*big.Float, error) { return PopulationStandardDeviation(ctx, floats(2, 4, 4, 4, 5, 5, 7, 9)...) }, "2", true},
+		{"test#10", func() (*big.Float, error) { return SampleStandardDeviation(ctx, floats(1, 2)...) }, "0.7071067812", false},
+		{"test#11", func() (*big.Float, error) { return Dot(ctx, floats(1, 2), floats(3, 0.5)) }, "4", true},
+		{"test#12", func() (*big.Float, error) { return Dot(ctx, []*big.Float{large, big.NewFloat(1)}, floats(1, 1)) }, "1.606938044e+60", false},
+		{"test#13", func() (*big.Float, error) { return Norm(ctx, floats(3, 4)) }, "5", true},
+		{"test#14", func() (*big.Float, error) { return Norm(ctx, floats(1, 1)) }, "1.414213562", false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn()
+			if err != nil {
+				t.Fatalf("Error calculating %s: %v", tt.name, err)
+			}
+
+			if text := fmt.Sprintf("%.10g", got); text != tt.want || (got.Acc() == big.Exact) != tt.exact {
+				t.Errorf("got %s (%s), want %s (exact: %t)", text, got.Acc(), tt.want, tt.exact)
+			}
+		})
+	}
+}
+
+func TestDescribeInfinities(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		args    []*big.Float
+		wantErr bool
+	}{
+		{"test#1", []*big.Float{big.NewFloat(math.Inf(1)), big.NewFloat(math.Inf(-1))}, true},
+		{"test#2", []*big.Float{big.NewFloat(1), big.NewFloat(math.Inf(-1)), big.NewFloat(math.Inf(1))}, true},
+		{"test#3", []*big.Float{big.NewFloat(1), big.NewFloat(math.Inf(1))}, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Describe(context.TODO(), tt.args...)
+			if (err != nil) != tt.wantErr || err != nil && !errors.Is(err, ErrDomain) {
+				t.Errorf("Describe(%v) error = %v, want error: %t", tt.args, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStatisticsErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		fn   func() (*big.Float, error)
+	}{
+		{"mean", func() (*big.Float, error) { return Mean(context.TODO()) }},
+		{"median", func() (*big.Float, error) { return Median(context.TODO()) }},
+		{"var", func() (*big.Float, error) { return SampleVariance(context.TODO(), big.NewFloat(1)) }},
+		{"quantile", func() (*big.Float, error) { return Quantile(context.TODO(), big.NewFloat(1.5), big.NewFloat(1)) }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := tt.fn(); err == nil {
+				t.Errorf("%s() = %v, want error", tt.name, got)
+			}
+		})
+	}
+}
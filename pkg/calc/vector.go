@@ -0,0 +1,76 @@
+package calc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+)
+
+// Cross calculates the cross product of the three-dimensional vectors a and b,
+// i.e., (a2*b3 - a3*b2, a3*b1 - a1*b3, a1*b2 - a2*b1)
+func Cross(ctx context.Context, a, b []*big.Float) ([]*big.Float, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(a) != 3 || len(b) != 3 {
+		return nil, fmt.Errorf("cross function requires two vectors of length 3")
+	}
+
+	prec := precision(append(append([]*big.Float{}, a...), b...)...)
+	result := make([]*big.Float, 3)
+	for i := range result {
+		j, k := (i+1)%3, (i+2)%3
+		result[i] = difference(product(a[j], b[k]), product(a[k], b[j])).SetPrec(prec)
+	}
+
+	return result, nil
+}
+
+// Dot calculates the dot product of the vectors a and b, i.e., sum(a[i] * b[i])
+func Dot(ctx context.Context, a, b []*big.Float) (*big.Float, error) {
+	if len(a) != len(b) {
+		return nil, fmt.Errorf("dot function requires two vectors of the same length, got %d and %d", len(a), len(b))
+	}
+
+	prec := precision(append(append([]*big.Float{}, a...), b...)...)
+	result, err := dot(ctx, a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.SetPrec(prec), nil
+}
+
+// Norm calculates the Euclidean norm of the vector a, i.e., sqrt(sum(a[i]^2))
+func Norm(ctx context.Context, a []*big.Float) (*big.Float, error) {
+	if len(a) == 0 {
+		return nil, fmt.Errorf("norm function requires at least 1 element")
+	}
+
+	prec := precision(a...)
+	result, err := dot(ctx, a, a)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Sign() == 0 {
+		return result.SetPrec(prec), nil
+	}
+
+	return new(big.Float).SetPrec(prec + guardBits).Sqrt(result).SetPrec(prec), nil
+}
+
+// dot calculates the dot product of the vectors a and b of the same length without rounding, see sum.
+func dot(ctx context.Context, a, b []*big.Float) (*big.Float, error) {
+	products := make([]*big.Float, len(a))
+	for i := range a {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		products[i] = product(a[i], b[i])
+	}
+
+	return sum(ctx, products...)
+}
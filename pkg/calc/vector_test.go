@@ -0,0 +1,72 @@
+package calc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+)
+
+func TestVectors(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		a, b      []float64
+		wantDot   string
+		wantCross string
+		wantNorm  string
+	}{
+		{"test#1", []float64{1, 2, 3}, []float64{4, 5, 6}, "32", "[-3 6 -3]", "3.741657387"},
+		{"test#2", []float64{1, 0, 0}, []float64{0, 1, 0}, "0", "[0 0 1]", "1"},
+		{"test#3", []float64{3, 4}, []float64{-4, 3}, "0", "", "5"},
+		{"test#4", []float64{0, 0, 0}, []float64{1, 2, 3}, "0", "[0 0 0]", "0"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := floats(tt.a...), floats(tt.b...)
+
+			if got, err := Dot(context.TODO(), a, b); err != nil {
+				t.Errorf("Error calculating dot(%v, %v): %v", tt.a, tt.b, err)
+			} else if text := got.Text('g', 10); text != tt.wantDot {
+				t.Errorf("Dot(%v, %v) = %s, want %s", tt.a, tt.b, text, tt.wantDot)
+			}
+
+			if got, err := Norm(context.TODO(), a); err != nil {
+				t.Errorf("Error calculating norm(%v): %v", tt.a, err)
+			} else if text := got.Text('g', 10); text != tt.wantNorm {
+				t.Errorf("Norm(%v) = %s, want %s", tt.a, text, tt.wantNorm)
+			}
+
+			got, err := Cross(context.TODO(), a, b)
+			if tt.wantCross == "" {
+				if err == nil {
+					t.Errorf("Cross(%v, %v) = %v, want error", tt.a, tt.b, got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Error calculating cross(%v, %v): %v", tt.a, tt.b, err)
+			} else if text := text(got); text != tt.wantCross {
+				t.Errorf("Cross(%v, %v) = %s, want %s", tt.a, tt.b, text, tt.wantCross)
+			}
+		})
+	}
+}
+
+func TestDotLengthMismatch(t *testing.T) {
+	if got, err := Dot(context.TODO(), floats(1, 2), floats(1, 2, 3)); err == nil {
+		t.Errorf("Dot() = %v, want error", got)
+	}
+}
+
+func TestNormPrecision(t *testing.T) {
+	two, _ := new(big.Float).SetPrec(200).SetString("2")
+	got, err := Norm(context.TODO(), []*big.Float{two, two})
+	if err != nil {
+		t.Fatalf("Error calculating norm: %v", err)
+	}
+
+	// sqrt(8) = 2*sqrt(2)
+	want := "2.82842712474619009760337744841939615713934375075389614635336"
+	if text := got.Text('g', 60); text != want {
+		t.Errorf("Norm() = %s, want %s", text, want)
+	}
+}
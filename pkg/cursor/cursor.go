@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/sarumaj/edu-taschenrechner/pkg/parser"
@@ -36,6 +37,7 @@ type CursorInterface[T any] interface {
 	Minus() T
 	Plus() T
 	Times() T
+	Tolerance() T
 
 	Arccos() T
 	Arcsin() T
@@ -52,6 +54,19 @@ type CursorInterface[T any] interface {
 	SquareRoot() T
 	Tan() T
 
+	BinomCdf() T
+	BinomPdf() T
+	Chi2Cdf() T
+	Erf() T
+	Erfc() T
+	Function(string) T
+	InvNorm() T
+	NormCdf() T
+	NormPdf() T
+	PoissonCdf() T
+	PoissonPdf() T
+	Tcdf() T
+
 	Euler() T
 	Pi() T
 	Zero() T
@@ -80,6 +95,8 @@ type CursorInterface[T any] interface {
 	EqualsWithFormat(format byte) T
 
 	Check() error
+	Exact() bool
+	Result() parser.Value
 	String() string
 }
 
@@ -89,9 +106,11 @@ type cursor struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 	err    error
+	exact  bool
 	char   rune
 	ready  bool
 	parser parser.Parser
+	result parser.Value
 	text   *runes.Sequence
 }
 
@@ -102,7 +121,7 @@ func (c *cursor) abortFunction() (aborted bool) {
 		return false
 	}
 
-	for !runes.IsAnyOf(c.text.Last(), "+-×÷") && runes.IsValid(c.text.Last()) {
+	for !runes.IsAnyOf(c.text.Last(), "+-±×÷") && runes.IsValid(c.text.Last()) {
 		c.text.Backspace()
 		aborted = true
 	}
@@ -116,7 +135,7 @@ func (c *cursor) abortFunction() (aborted bool) {
 
 // abortOperation aborts a binary operation if the last character is an operator.
 func (c *cursor) abortOperation() (aborted bool) {
-	for runes.IsAnyOf(c.text.Last(), "+-×÷.^") {
+	for runes.IsAnyOf(c.text.Last(), "+-±×÷.^") {
 		c.text.Backspace()
 		aborted = true
 	}
@@ -134,7 +153,7 @@ func (c *cursor) binary(op rune, opts ...rune) *cursor {
 	c.prepare()
 	defer c.exhaust()
 
-	if !runes.IsAnyOf(op, "×÷+-^") {
+	if !runes.IsAnyOf(op, "×÷+-±^") {
 		return c.Error(fmt.Errorf("unsupported operator: %c", op))
 	}
 
@@ -164,7 +183,7 @@ func (c *cursor) binary(op rune, opts ...rune) *cursor {
 		}
 
 	default:
-		for runes.IsAnyOf(c.text.Last(), "-×÷+^") { // abort operation
+		for runes.IsAnyOf(c.text.Last(), "-±×÷+^") { // abort operation
 			c.text.Backspace()
 		}
 	}
@@ -203,7 +222,7 @@ func (c *cursor) closeBrackets() (closed bool) {
 	}
 
 	if removed {
-		for !runes.IsAnyOf(c.text.Last(), "+-×÷") && runes.IsValid(c.text.Last()) {
+		for !runes.IsAnyOf(c.text.Last(), "+-±×÷") && runes.IsValid(c.text.Last()) {
 			c.text.Backspace()
 		}
 		c.text.Backspace()
@@ -238,6 +257,17 @@ func (c *cursor) function(name string) *cursor {
 	return c
 }
 
+// isIdentifier reports whether name is a valid function name, i.e., a letter followed by word characters.
+func isIdentifier(name string) bool {
+	for i, r := range name {
+		if !runes.IsWord(r) || (i == 0 && !runes.IsLetter(r)) {
+			return false
+		}
+	}
+
+	return name != ""
+}
+
 // prepare prepares the input text for a new calculation.
 // If the input text ends with a cursor, it is removed.
 // If the input text equals NaN, the screen is cleared.
@@ -293,7 +323,7 @@ func (c *cursor) Brackets() *cursor {
 
 	switch {
 	case // just open
-		runes.IsAnyOf(c.text.Last(), "(+-×÷√^,"),
+		runes.IsAnyOf(c.text.Last(), "(+-±×÷√^,"),
 		!runes.IsValid(c.text.Last()):
 
 		c.text.Append("(")
@@ -411,6 +441,7 @@ func (c *cursor) Do(operator string) *cursor {
 		"-":     c.Minus,
 		"+":     c.Plus,
 		"×":     c.Times,
+		"±":     c.Tolerance,
 		"0":     c.Zero,
 		"1":     c.One,
 		"2":     c.Two,
@@ -442,10 +473,27 @@ func (c *cursor) Do(operator string) *cursor {
 		"ln":    c.Ln,
 		"gdc":   c.Gdc,
 		"lcm":   c.Lcm,
+
+		"normpdf":    c.NormPdf,
+		"normcdf":    c.NormCdf,
+		"invnorm":    c.InvNorm,
+		"binompdf":   c.BinomPdf,
+		"binomcdf":   c.BinomCdf,
+		"poissonpdf": c.PoissonPdf,
+		"poissoncdf": c.PoissonCdf,
+		"tcdf":       c.Tcdf,
+		"chi2cdf":    c.Chi2Cdf,
+		"erf":        c.Erf,
+		"erfc":       c.Erfc,
 	}[operator]; ok {
 		return fn()
 	}
 
+	// open a call of any other function, e.g., "fit1("
+	if name, ok := strings.CutSuffix(operator, "("); ok && isIdentifier(name) {
+		return c.Function(name)
+	}
+
 	return c.Error(fmt.Errorf("unknown operator: %s", operator))
 }
 
@@ -458,6 +506,9 @@ func (c *cursor) Error(err error) *cursor {
 	return c
 }
 
+// Function opens a call of the function with the given name in the input text.
+func (c *cursor) Function(name string) *cursor { return c.function(name) }
+
 // Equals evaluates the input text and displays the result.
 // It uses the EqualsWithFormat method to display the result using the default format.
 func (c *cursor) Equals() *cursor { return c.EqualsWithFormat('f') }
@@ -481,7 +532,7 @@ func (c *cursor) EqualsWithFormat(format byte) *cursor {
 	}
 
 	// evaluate input text
-	result, err := c.parser.Parse(c.ctx, "save("+c.text.String()+")")
+	result, exact, err := c.parser.ParseExact(c.ctx, "save("+c.text.String()+")")
 	if err != nil {
 		return c.Error(err)
 	}
@@ -491,11 +542,18 @@ func (c *cursor) EqualsWithFormat(format byte) *cursor {
 	}
 
 	// display result
+	c.result, c.exact = result, exact
 	c.text.Clear()
-	c.text.Append(result.Text(format, -1))
+	c.text.Append(parser.Text(result, format, -1))
 	return c
 }
 
+// Exact reports whether the result of the last evaluation is exact, see parser.ParseExact.
+func (c *cursor) Exact() bool { return c.exact }
+
+// Result returns the result of the last evaluation or nil if nothing has been evaluated yet.
+func (c *cursor) Result() parser.Value { return c.result }
+
 // String returns the input text as a string.
 func (c *cursor) String() string { return c.text.String() }
 
@@ -509,10 +567,11 @@ func (c *cursor) Radians() *cursor   { return c.unit("÷1°") }
 /*
 Binary Operators
 */
-func (c *cursor) Divide() *cursor { return c.binary('÷') }
-func (c *cursor) Minus() *cursor  { return c.binary('-') }
-func (c *cursor) Plus() *cursor   { return c.binary('+') }
-func (c *cursor) Times() *cursor  { return c.binary('×') }
+func (c *cursor) Divide() *cursor    { return c.binary('÷') }
+func (c *cursor) Minus() *cursor     { return c.binary('-') }
+func (c *cursor) Plus() *cursor      { return c.binary('+') }
+func (c *cursor) Times() *cursor     { return c.binary('×') }
+func (c *cursor) Tolerance() *cursor { return c.binary('±') }
 
 /*
 Functions
@@ -532,6 +591,21 @@ func (c *cursor) Square() *cursor     { return c.binary('^', '2') }
 func (c *cursor) SquareRoot() *cursor { return c.character('√') }
 func (c *cursor) Tan() *cursor        { return c.function("tan") }
 
+/*
+Probability Distributions
+*/
+func (c *cursor) BinomCdf() *cursor   { return c.function("binomcdf") }
+func (c *cursor) BinomPdf() *cursor   { return c.function("binompdf") }
+func (c *cursor) Chi2Cdf() *cursor    { return c.function("chi2cdf") }
+func (c *cursor) Erf() *cursor        { return c.function("erf") }
+func (c *cursor) Erfc() *cursor       { return c.function("erfc") }
+func (c *cursor) InvNorm() *cursor    { return c.function("invnorm") }
+func (c *cursor) NormCdf() *cursor    { return c.function("normcdf") }
+func (c *cursor) NormPdf() *cursor    { return c.function("normpdf") }
+func (c *cursor) PoissonCdf() *cursor { return c.function("poissoncdf") }
+func (c *cursor) PoissonPdf() *cursor { return c.function("poissonpdf") }
+func (c *cursor) Tcdf() *cursor       { return c.function("tcdf") }
+
 /*
 Numbers and Constants
 */
@@ -550,10 +624,16 @@ func (c *cursor) Nine() *cursor  { return c.character('9') }
 
 // New creates new cursor.
 func New(text *runes.Sequence, timeout time.Duration, parserOpts ...parser.Option) Cursor {
+	return NewWithParser(text, timeout, parser.NewParser(parserOpts...))
+}
+
+// NewWithParser creates new cursor evaluating with the given parser,
+// e.g., to share the parser and its cache of results among cursors, see parser.WithCache.
+func NewWithParser(text *runes.Sequence, timeout time.Duration, p parser.Parser) Cursor {
 	c := cursor{
 		char:   '_',
 		text:   text,
-		parser: parser.NewParser(parserOpts...),
+		parser: p,
 	}
 
 	if timeout > 0 {
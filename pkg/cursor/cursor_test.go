@@ -3,6 +3,7 @@ package cursor
 import (
 	"testing"
 
+	"github.com/sarumaj/edu-taschenrechner/pkg/parser"
 	"github.com/sarumaj/edu-taschenrechner/pkg/runes"
 )
 
@@ -36,6 +37,7 @@ func TestExampleFor_Cursor(t *testing.T) {
 		{"test#18", get().One().Minus().Zero().Delete().Minus().One().Equals().Brackets().Two().Three(), "(23_"},
 		{"test#19", get().Six().Plus().Five().Eight().Minus().Clear().Clear().Seven().Five(), "75_"},
 		{"test#20", get().Nine().DecimalPoint().Six().Three().DecimalPoint().DecimalPoint().Plus().Three().Seven(), "ANS×9.63+37_"},
+		{"test#21", get().Nine().Tolerance().Plus().Tolerance().DecimalPoint().Five().Minus(), "ANS×9±5-_"},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			if got := tt.args.String(); got != tt.want {
@@ -78,6 +80,9 @@ func TestExampleFor_Do(t *testing.T) {
 		{"test#17", args{"9", false}, "-6×((2-7)×0.6)+9_"},
 		{"test#18", args{"=", false}, "NaN"},
 		{"test#19", args{"=", true}, "_"},
+		{"test#20", args{"fit1(", true}, "fit1(_"},
+		{"test#21", args{"4", false}, "fit1(4_"},
+		{"test#22", args{"1fit(", false}, "NaN"},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.args.requestNewSetup {
@@ -93,3 +98,28 @@ func TestExampleFor_Do(t *testing.T) {
 		})
 	}
 }
+
+func TestExampleFor_Exact(t *testing.T) {
+	save := parser.WithFunc("save", func(args ...parser.Value) (parser.Value, error) { return args[0], nil })
+
+	for _, tt := range []struct {
+		name  string
+		args  string
+		want  string
+		exact bool
+	}{
+		{"test#1", "1/4+2^10_", "1024.25", true},
+		{"test#2", "1/3_", "0.3333333333333333", false},
+		{"test#3", "√2_", "1.4142135623730951", false},
+		{"test#4", "√(0.25)_", "0.5", true},
+		{"test#5", "10±0.5_", "[9.5, 10.5]", false},
+		{"test#6", "2×(1±0)_", "2", true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(runes.NewSequence(tt.args), 0, save).Equals()
+			if got := c.String(); got != tt.want || c.Exact() != tt.exact {
+				t.Errorf("Equals(%q) failed, got: %q (exact: %t), want: %q (exact: %t)", tt.args, got, c.Exact(), tt.want, tt.exact)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"math"
+	"math/big"
+	"strings"
+)
+
+// approximated reports whether the constant or variable of the name is marked as approximate, see WithApproximate.
+// The mark applies from the layer marking it to the innermost layer defining the name, see Scope.
+func (p *parser) approximated(name string) bool {
+	for layer := p; layer != nil; layer = layer.parent {
+		if layer.approximate[name] {
+			return true
+		}
+
+		if _, ok := layer.constants[name]; ok {
+			return false
+		}
+
+		if _, ok := layer.variables[name]; ok {
+			return false
+		}
+	}
+
+	return false
+}
+
+// accurate reports whether the scalars of the value are exact as far as their accuracy tells,
+// i.e., whether the operations computing them did not round them.
+func accurate(value Value) bool {
+	switch value := value.(type) {
+	case *big.Float:
+		return value != nil && value.Acc() == big.Exact
+
+	case List:
+		for _, element := range value {
+			if !accurate(element) {
+				return false
+			}
+		}
+
+		return true
+
+	}
+
+	return false
+}
+
+// exactRoot reports whether root is the exact square root of x.
+// The accuracy of square roots is not computed by math/big, hence the root is squared at twice its precision.
+func exactRoot(x, root *big.Float) bool {
+	if root.IsInf() || root.Sign() == 0 {
+		return true
+	}
+
+	square := new(big.Float).SetPrec(2*root.Prec()).Mul(root, root)
+	return square.Cmp(x) == 0
+}
+
+// exactPower reports whether power is the exact power of the base and the integer exponent, see calc.Pow.
+// The power is computed by repeated multiplications, hence it is exact if the odd part of the mantissa of the base
+// raised to the exponent fits into the precision of the power, or if the base is a power of 2.
+func exactPower(base, exponent, power *big.Float) bool {
+	n, accuracy := exponent.Int64()
+	switch {
+	case accuracy != big.Exact || power.IsInf() != base.IsInf():
+		return false
+
+	case n == 0, base.Sign() == 0, base.IsInf():
+		return true
+
+	}
+
+	// odd part of the mantissa of the base, e.g., 3 of 12 or 0.375
+	odd, _ := new(big.Float).SetMantExp(base, int(base.MinPrec())-base.MantExp(nil)).Int(nil)
+	odd.Abs(odd)
+	if odd.BitLen() == 1 { // power of 2
+		return true
+	}
+
+	prec := int64(power.Prec())
+	if n < 0 || n > prec || n*int64(odd.BitLen()-1) >= prec {
+		return false
+	}
+
+	return int64(new(big.Int).Exp(odd, big.NewInt(n), nil).BitLen()) <= prec
+}
+
+// Digits formats the value with all digits guaranteed by its exactness, see ParseExact.
+// Exact scalars are printed with their complete decimal expansion, e.g., 2^-10 as 0.0009765625,
+// inexact ones with the significant digits carried by their precision, e.g., 1/3 with 15 digits for 53 bits.
+func Digits(value Value, exact bool) string {
+	switch value := value.(type) {
+	case *big.Float:
+		switch {
+		case value == nil:
+			return "<nil>"
+
+		case value.IsInf():
+			return Text(value, 'g', -1)
+
+		case exact: // binary fractions have finite decimal expansions
+			return value.Text('f', max(0, int(value.MinPrec())-value.MantExp(nil)))
+
+		}
+
+		return value.Text('g', max(1, int(float64(value.Prec()-1)*math.Log10(2))))
+
+	case List:
+		texts := make([]string, len(value))
+		for i, element := range value {
+			texts[i] = Digits(element, exact)
+		}
+
+		return "[" + strings.Join(texts, ", ") + "]"
+
+	case Interval: // the bounds are rounded outwards to the significant digits of the lower precision
+		return Text(value, 'g', max(1, int(float64(min(value.Lo.Prec(), value.Hi.Prec())-1)*math.Log10(2))))
+
+	}
+
+	return ""
+}
@@ -0,0 +1,125 @@
+package parser
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestExampleFor_ParserParseExact(t *testing.T) {
+	p := NewParser(
+		WithConst("PI", math.Pi),
+		WithApproximate("PI"),
+		WithConst("k", 3),
+		WithVar("x", func() float64 { return 0.5 }),
+		WithFunc("sin", math.Sin),
+		WithFunc("sqr", func(x *big.Float) (*big.Float, error) { return new(big.Float).Mul(x, x), nil }),
+		WithFunc("series", func(x *big.Float) (*big.Float, error) { return x, nil }),
+		WithApproximate("series"),
+	)
+
+	for _, tt := range []struct {
+		name  string
+		args  string
+		want  string
+		exact bool
+	}{
+		{"test#1", "1/4 + 2^10", "1024.25", true},
+		{"test#2", "1/3", "0.3333333333", false},
+		{"test#3", "0.1", "0.1", false},
+		{"test#4", "0.5 + 0.25 - 1e3", "-999.25", true},
+		{"test#5", "√16 + √0.25", "4.5", true},
+		{"test#6", "√2", "1.414213562", false},
+		{"test#7", "2^-3 * 3^33", "6.948825708e+14", true},
+		{"test#8", "3^34", "1.66771817e+16", false},
+		{"test#9", "3^-1", "0.3333333333", false},
+		{"test#10", "2^52 + 1", "4.503599627e+15", true},
+		{"test#11", "2^53 + 1", "9.007199255e+15", false},
+		{"test#12", "20!", "2.432902008e+18", true},
+		{"test#13", "25!", "1.551121004e+25", false},
+		{"test#14", "PI", "3.141592654", false},
+		{"test#15", "k*x + sqr(3)", "10.5", true},
+		{"test#16", "sin(0)", "0", false},
+		{"test#17", "series(1)", "1", false},
+		{"test#18", "[1, 2] * 2", "[2, 4]", true},
+		{"test#19", "[1, 0.1]", "[1, 0.1]", false},
+		{"test#20", "len([1, 2]) + len([0.1])", "3", false},
+		{"test#21", "30°", "0.5235987756", false},
+		{"test#22", "0° + [1, 2, 3][2]", "2", true},
+		{"test#23", "diff(x^3, x, 2)", "12", true},
+		{"test#24", "diff(x^3, x, 0.1)", "0.03", false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, p := range []*parser{p, p.With(WithParallelism(2))} {
+				got, exact, err := p.ParseExact(context.TODO(), tt.args)
+				switch {
+				case err != nil:
+					t.Errorf("Error parsing expression %q: %v", tt.args, err)
+
+				case got.Text('g', 10) != tt.want || exact != tt.exact:
+					t.Errorf("Result of %q: %s (exact: %t), want %s (exact: %t)", tt.args, got.Text('g', 10), exact, tt.want, tt.exact)
+
+				}
+			}
+		})
+	}
+}
+
+func TestApproximateScope(t *testing.T) {
+	library := NewParser(WithConst("PI", math.Pi), WithConst("g", 9.80665), WithApproximate("PI", "g", "undefined"))
+	user := library.Scope(WithConst("PI", 3))
+
+	for _, tt := range []struct {
+		name   string
+		parser *parser
+		args   string
+		exact  bool
+	}{
+		{"test#1", library, "PI", false},
+		{"test#2", library, "g", false},
+		{"test#3", user, "PI", true},
+		{"test#4", user.Scope(WithApproximate("PI")), "PI", false},
+		{"test#5", library.With(WithConst("g", 10)), "g", true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, exact, err := tt.parser.ParseExact(context.TODO(), tt.args); err != nil || exact != tt.exact {
+				t.Errorf("Exactness of %q: %t (%v), want %t", tt.args, exact, err, tt.exact)
+			}
+		})
+	}
+
+	for _, constant := range user.ListConstants() {
+		if constant.Approximate != (constant.Name == "g") {
+			t.Errorf("Constant %s listed as approximate: %t", constant.Name, constant.Approximate)
+		}
+	}
+}
+
+func TestExampleFor_Digits(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		args string
+		want string
+	}{
+		{"test#1", "2^-10", "0.0009765625"},
+		{"test#2", "2^70", "1180591620717411303424"},
+		{"test#3", "-1.5 + 0", "-1.5"},
+		{"test#4", "1/3", "0.333333333333333"},
+		{"test#5", "[0.5, 2^-2, 7]", "[0.5, 0.25, 7]"},
+		{"test#6", "[1, 2/3]", "[1, 0.666666666666667]"},
+		{"test#7", "0", "0"},
+		{"test#8", "-Inf", "-∞"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			value, exact, err := NewParser().ParseExact(context.TODO(), tt.args)
+			if err != nil {
+				t.Fatalf("Error parsing expression %q: %v", tt.args, err)
+			}
+
+			if got := Digits(value, exact); got != tt.want {
+				t.Errorf("Digits of %q: %s, want %s", tt.args, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package parser
+
+import (
+	"container/list"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// cache is a bounded cache of the results of calls of pure functions, see WithCache and WithPure.
+// The least recently used results are evicted first.
+type cache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List // most recently used entry first
+}
+
+// entry is a cached result of a call.
+type entry struct {
+	key   string
+	value Value
+}
+
+// newCache returns a cache holding up to size results, it returns nil if the size is zero or less.
+func newCache(size int) *cache {
+	if size <= 0 {
+		return nil
+	}
+
+	return &cache{size: size, entries: make(map[string]*list.Element), order: list.New()}
+}
+
+// empty returns an empty cache of the same size, it returns nil for a nil cache.
+func (c *cache) empty() *cache {
+	if c == nil {
+		return nil
+	}
+
+	return newCache(c.size)
+}
+
+// get returns a copy of the result cached by the key and marks it as recently used.
+func (c *cache) get(key string) (Value, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return copyValue(element.Value.(*entry).value), true
+}
+
+// put caches a copy of the result by the key evicting the least recently used result if the cache is full.
+func (c *cache) put(key string, value Value) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*entry).value = copyValue(value)
+		c.order.MoveToFront(element)
+		return
+	}
+
+	if c.order.Len() >= c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*entry).key)
+	}
+
+	c.entries[key] = c.order.PushFront(&entry{key: key, value: copyValue(value)})
+}
+
+// callKey returns the key of the call of the function with the arguments,
+// the arguments are encoded exactly along with their precision, e.g., f(0x.8p+2/53).
+func callKey(name string, args []Value) string {
+	var key strings.Builder
+	key.WriteString(name)
+	key.WriteByte('(')
+	for i, arg := range args {
+		if i > 0 {
+			key.WriteString(", ")
+		}
+		writeKey(&key, arg)
+	}
+	key.WriteByte(')')
+
+	return key.String()
+}
+
+// writeKey writes the exact encoding of the value to the key.
+func writeKey(key *strings.Builder, value Value) {
+	switch value := value.(type) {
+	case *big.Float:
+		if value == nil {
+			key.WriteString("nil")
+			return
+		}
+
+		key.WriteString(value.Text('p', 0))
+		key.WriteByte('/')
+		key.WriteString(strconv.FormatUint(uint64(value.Prec()), 10))
+
+	case List:
+		key.WriteByte('[')
+		for i, element := range value {
+			if i > 0 {
+				key.WriteString(", ")
+			}
+			writeKey(key, element)
+		}
+		key.WriteByte(']')
+
+	case Interval:
+		key.WriteByte('{')
+		writeKey(key, value.Lo)
+		key.WriteString(", ")
+		writeKey(key, value.Hi)
+		key.WriteByte('}')
+
+	}
+}
+
+// copyValue returns a deep copy of the value, so that cached results cannot be modified by the callers.
+func copyValue(value Value) Value {
+	switch value := value.(type) {
+	case *big.Float:
+		if value == nil {
+			return value
+		}
+
+		return new(big.Float).Copy(value)
+
+	case List:
+		list := make(List, len(value))
+		for i, element := range value {
+			list[i] = copyValue(element)
+		}
+
+		return list
+
+	case Interval:
+		return Interval{copyValue(value.Lo).(*big.Float), copyValue(value.Hi).(*big.Float)}
+
+	}
+
+	return value
+}
@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+)
+
+func TestExampleFor_ParserWithCache(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		size  int
+		args  string
+		want  string
+		calls int64
+	}{
+		{"test#1", 8, "f(2) + f(2)", "4", 1},
+		{"test#2", 8, "f(2) + f(3) + f(2) * f(3)", "11", 2},
+		{"test#3", 1, "f(2) + f(3) + f(3) + f(2)", "10", 3},
+		{"test#4", 2, "f(1) + f(2) + f(1) + f(3) + f(1) + f(2)", "10", 4},
+		{"test#5", 8, "len(f([1, 2])) + len(f([1, 2])) + len(f([1, 2, 3]))", "7", 2},
+		{"test#6", 8, "f(f(2)) + f(2)", "4", 1},
+		{"test#7", 8, "g(2) + g(2)", "4", 2},
+		{"test#8", 0, "f(2) + f(2)", "4", 2},
+		{"test#9", 8, "f(2) + f(2.5) + f(-2)", "2.5", 3},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int64
+			identity := func(args ...Value) (Value, error) {
+				calls.Add(1)
+				return args[0], nil
+			}
+
+			p := NewParser(
+				WithFunc("f", identity), WithArity("f", 1, 1),
+				WithFunc("g", identity), WithArity("g", 1, 1),
+				WithPure("f"),
+				WithCache(tt.size),
+			)
+
+			got, err := p.ParseValue(context.TODO(), tt.args)
+			switch {
+			case err != nil:
+				t.Errorf("Error parsing expression %q: %v", tt.args, err)
+
+			case got.Text('g', 10) != tt.want:
+				t.Errorf("Result of %q: %s, want %s", tt.args, got.Text('g', 10), tt.want)
+
+			case calls.Load() != tt.calls:
+				t.Errorf("Calls evaluating %q: %d, want %d", tt.args, calls.Load(), tt.calls)
+
+			}
+		})
+	}
+}
+
+func TestCacheIsolation(t *testing.T) {
+	var calls atomic.Int64
+	p := NewParser(
+		WithFunc("double", func(x *big.Float) (*big.Float, error) {
+			calls.Add(1)
+			return new(big.Float).Add(x, x), nil
+		}),
+		WithPure("double"),
+		WithCache(8),
+	)
+
+	// results are cached across evaluations of the parser
+	for i := 0; i < 3; i++ {
+		if got, err := p.Parse(context.TODO(), "double(21)"); err != nil || got.Text('g', 10) != "42" || calls.Load() != 1 {
+			t.Errorf("Result of evaluation #%d: %v (%v) after %d calls, want 42 after 1 call", i+1, got, err, calls.Load())
+		}
+	}
+
+	// cached results cannot be modified by the callers
+	got, _ := p.Parse(context.TODO(), "double(21)")
+	got.SetInt64(0)
+	if got, _ := p.Parse(context.TODO(), "double(21)"); got.Text('g', 10) != "42" || calls.Load() != 1 {
+		t.Errorf("Result after modifying a cached result: %v after %d calls, want 42 after 1 call", got, calls.Load())
+	}
+
+	// derived parsers start with an empty cache, since they may redefine functions
+	derived := p.With(WithFunc("double", func(x float64) float64 { return 3 * x }))
+	if got, _ := derived.Parse(context.TODO(), "double(21)"); got.Text('g', 10) != "63" {
+		t.Errorf("Result of a redefined function: %v, want 63", got)
+	}
+
+	if got, _ := p.Scope().Parse(context.TODO(), "double(21)"); got.Text('g', 10) != "42" || calls.Load() != 2 {
+		t.Errorf("Result of a layer: %v after %d calls, want 42 after 2 calls", got, calls.Load())
+	}
+
+	// registering a function again unmarks it
+	for _, f := range derived.ListFunctions() {
+		if f.Name == "double" && f.Pure {
+			t.Errorf("Function %s registered again is pure", f.Name)
+		}
+	}
+}
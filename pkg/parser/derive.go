@@ -0,0 +1,453 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+)
+
+// Derive returns a new parse tree for the derivative of the expression given by root with respect to the variable.
+// It supports the operators + - * / ^ √ °, and the functions sin, cos, tan, arcsin, arccos, arctan, ln and log.
+// Subtrees which do not depend on the variable are treated as constants, the derivative is simplified while it is built
+// and by Simplify afterwards, e.g., the derivative of x^2 is 2*x rather than 2*x^1*1.
+// Since the derivative only exists where the expression is defined, it may cancel subtrees, e.g., x/x = 1.
+// Within expressions, diff(expr, x) evaluates the derivative at the current value of x
+// and diff(expr, x, a) at x = a, e.g., diff(x^2, x, 3) evaluates to 6.
+func Derive(root Node, variable string) (Node, error) {
+	n, ok := root.(*node)
+	if !ok || n == nil {
+		return nil, fmt.Errorf("missing expression to differentiate")
+	}
+
+	result, err := newDeriver(n, variable).derive(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return simplifier{defined: true}.simplify(result), nil
+}
+
+// deriver differentiates a parse tree with respect to a variable, see Derive.
+type deriver struct {
+	variable  string
+	dependent map[*node]bool // nodes of the tree whose subtrees contain the variable
+}
+
+// newDeriver returns the deriver of the tree given by root with respect to the variable x.
+// The nodes depending on x are determined once without recursion, so that deep trees are differentiated in linear time.
+func newDeriver(root *node, x string) deriver {
+	// collect the nodes in pre-order, so that the children follow their parents
+	var nodes []*node
+	for stack := []*node{root}; len(stack) > 0; {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if n != nil {
+			nodes = append(nodes, n)
+			stack = append(stack, n.left, n.right)
+		}
+	}
+
+	dependent := make(map[*node]bool, len(nodes))
+	for i := len(nodes) - 1; i >= 0; i-- {
+		n := nodes[i]
+		dependent[n] = (n.IsLeaf() && n.value == x) || dependent[n.left] || dependent[n.right]
+	}
+
+	return deriver{variable: x, dependent: dependent}
+}
+
+// derive returns the derivative of the subtree n.
+func (d deriver) derive(n *node) (*node, error) {
+	if n == nil {
+		return nil, fmt.Errorf("missing operand")
+	}
+
+	if !d.dependent[n] {
+		return numberNode(0), nil
+	}
+
+	if n.IsLeaf() { // the variable itself
+		return numberNode(1), nil
+	}
+
+	switch n.value {
+	case "+", "-":
+		if n.value == "-" && n.right == nil { // unary minus
+			du, err := d.derive(n.left)
+			if err != nil {
+				return nil, err
+			}
+
+			return negateNode(du), nil
+		}
+
+		du, dv, err := d.deriveBoth(n)
+		if err != nil {
+			return nil, err
+		}
+
+		if n.value == "+" {
+			return sumNode(du, dv), nil
+		}
+
+		return differenceNode(du, dv), nil
+
+	case "*": // (u*v)' = u'*v + u*v'
+		du, dv, err := d.deriveBoth(n)
+		if err != nil {
+			return nil, err
+		}
+
+		return sumNode(productNode(du, clone(n.right)), productNode(clone(n.left), dv)), nil
+
+	case "/": // (u/v)' = (u'*v - u*v') / v^2
+		du, dv, err := d.deriveBoth(n)
+		if err != nil {
+			return nil, err
+		}
+
+		return quotientNode(
+			differenceNode(productNode(du, clone(n.right)), productNode(clone(n.left), dv)),
+			powerNode(clone(n.right), numberNode(2)),
+		), nil
+
+	case "^":
+		return d.derivePower(n)
+
+	case "√": // (√u)' = u' / (2*√u)
+		du, err := d.derive(n.left)
+		if err != nil {
+			return nil, err
+		}
+
+		return quotientNode(du, productNode(numberNode(2), clone(n))), nil
+
+	case "°": // u° = u*π/180 is linear
+		du, err := d.derive(n.left)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewNode("°").SetLeft(du), nil
+
+	case "diff": // nested derivative diff(u, y), i.e., (u_y)'
+		if args := n.left; args != nil && args.right != nil && args.right.right == nil && args.right.left.IsLeaf() {
+			inner, err := newDeriver(args.left, args.right.left.value).derive(args.left)
+			if err != nil {
+				return nil, err
+			}
+
+			return newDeriver(inner, d.variable).derive(inner)
+		}
+
+		return nil, fmt.Errorf("cannot differentiate diff function with a given point")
+
+	case "!", "[", "[]", "@", "±":
+		return nil, fmt.Errorf("cannot differentiate operator %s", n.value)
+
+	}
+
+	return d.deriveFunc(n)
+}
+
+// deriveBoth returns the derivatives of the left and right operand of n.
+func (d deriver) deriveBoth(n *node) (du, dv *node, err error) {
+	if du, err = d.derive(n.left); err != nil {
+		return nil, nil, err
+	}
+
+	if dv, err = d.derive(n.right); err != nil {
+		return nil, nil, err
+	}
+
+	return du, dv, nil
+}
+
+// deriveFunc returns the derivative of the function call n using the chain rule.
+func (d deriver) deriveFunc(n *node) (*node, error) {
+	if n.left == nil || n.left.right != nil {
+		return nil, fmt.Errorf("cannot differentiate function %s: exactly 1 argument required", n.value)
+	}
+
+	u := n.left.left
+	du, err := d.derive(u)
+	if err != nil {
+		return nil, err
+	}
+
+	var outer *node // derivative of the outer function evaluated at u
+	switch n.value {
+	case "sin": // sin' = cos
+		outer = callNode("cos", clone(u))
+
+	case "cos": // cos' = -sin
+		outer = differenceNode(numberNode(0), callNode("sin", clone(u)))
+
+	case "tan": // tan' = 1/cos^2
+		outer = quotientNode(numberNode(1), powerNode(callNode("cos", clone(u)), numberNode(2)))
+
+	case "arcsin": // arcsin' = 1/√(1-u^2)
+		outer = quotientNode(numberNode(1), NewNode("√").SetLeft(differenceNode(numberNode(1), powerNode(clone(u), numberNode(2)))))
+
+	case "arccos": // arccos' = -1/√(1-u^2)
+		outer = differenceNode(numberNode(0), quotientNode(numberNode(1), NewNode("√").SetLeft(differenceNode(numberNode(1), powerNode(clone(u), numberNode(2))))))
+
+	case "arctan": // arctan' = 1/(1+u^2)
+		outer = quotientNode(numberNode(1), sumNode(numberNode(1), powerNode(clone(u), numberNode(2))))
+
+	case "ln": // ln' = 1/u
+		return quotientNode(du, clone(u)), nil
+
+	case "log": // log' = 1/(u*ln(10))
+		return quotientNode(du, productNode(clone(u), callNode("ln", numberNode(10)))), nil
+
+	default:
+		return nil, fmt.Errorf("cannot differentiate function %s", n.value)
+
+	}
+
+	return productNode(outer, du), nil
+}
+
+// derivePower returns the derivative of the power n = u^v.
+func (d deriver) derivePower(n *node) (*node, error) {
+	u, v := n.left, n.right
+	du, dv, err := d.deriveBoth(n)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case !d.dependent[v]: // (u^v)' = v*u^(v-1)*u'
+		return productNode(productNode(clone(v), powerNode(clone(u), differenceNode(clone(v), numberNode(1)))), du), nil
+
+	case !d.dependent[u]: // (a^v)' = a^v*ln(a)*v'
+		if u.IsLeaf() && (u.value == "E" || u.value == "e") {
+			return productNode(clone(n), dv), nil
+		}
+
+		return productNode(productNode(clone(n), callNode("ln", clone(u))), dv), nil
+
+	}
+
+	// (u^v)' = u^v * (v'*ln(u) + v*u'/u)
+	return productNode(clone(n), sumNode(productNode(dv, callNode("ln", clone(u))), quotientNode(productNode(clone(v), du), clone(u)))), nil
+}
+
+// callNode creates a function call node with a single argument.
+func callNode(name string, arg *node) *node {
+	return NewNode(name).SetLeft(NewNode("").SetLeft(arg))
+}
+
+// clone returns a deep copy of n.
+func clone(n *node) *node {
+	if n == nil {
+		return nil
+	}
+
+	return &node{value: n.value, left: clone(n.left), right: clone(n.right)}
+}
+
+// differenceNode creates the node a - b, omitting subtractions of zero and folding numbers.
+func differenceNode(a, b *node) *node {
+	switch x, y := numeric(a), numeric(b); {
+	case x != nil && y != nil:
+		return numberNode(new(big.Rat).Sub(x, y))
+
+	case y != nil && y.Sign() == 0:
+		return a
+
+	case equal(a, b):
+		return numberNode(0)
+
+	}
+
+	return NewNode("-").SetLeft(a).SetRight(b)
+}
+
+// equal reports whether the trees a and b are structurally identical.
+func equal(a, b *node) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.value == b.value && equal(a.left, b.left) && equal(a.right, b.right)
+}
+
+// numberNode creates a leaf node holding the given number.
+// Fractions without terminating decimal expansion become quotients, e.g., 1/3.
+func numberNode[N int | *big.Rat](n N) *node {
+	switch n := any(n).(type) {
+	case int:
+		return &node{value: fmt.Sprint(n)}
+
+	case *big.Rat:
+		if n.IsInt() {
+			return &node{value: n.Num().String()}
+		}
+
+		if digits, ok := decimals(n.Denom()); ok {
+			return &node{value: n.FloatString(digits)}
+		}
+
+		return NewNode("/").SetLeft(&node{value: n.Num().String()}).SetRight(&node{value: n.Denom().String()})
+
+	}
+
+	return nil
+}
+
+// decimals returns the number of decimal places of the fractions with the given denominator
+// or false if their decimal expansion does not terminate, i.e., if it has prime factors other than 2 and 5.
+func decimals(denom *big.Int) (int, bool) {
+	twos := int(denom.TrailingZeroBits())
+	rest, fives := new(big.Int).Rsh(denom, uint(twos)), 0
+	for five, remainder := big.NewInt(5), new(big.Int); ; fives++ {
+		quotient, _ := new(big.Int).QuoRem(rest, five, remainder)
+		if remainder.Sign() != 0 {
+			break
+		}
+
+		rest = quotient
+	}
+
+	return max(twos, fives), rest.Cmp(big.NewInt(1)) == 0
+}
+
+// numeric returns the exact number held by the leaf n or nil if n is not a finite number.
+func numeric(n *node) *big.Rat {
+	if n == nil || !n.IsLeaf() {
+		return nil
+	}
+
+	if x, ok := new(big.Rat).SetString(n.value); ok {
+		return x
+	}
+
+	return nil
+}
+
+// powerNode creates the node a ^ b, omitting the exponents 0 and 1.
+func powerNode(a, b *node) *node {
+	if y := numeric(b); y != nil {
+		switch {
+		case y.Sign() == 0:
+			return numberNode(1)
+
+		case y.Cmp(big.NewRat(1, 1)) == 0:
+			return a
+
+		}
+	}
+
+	return NewNode("^").SetLeft(a).SetRight(b)
+}
+
+// productNode creates the node a * b, omitting factors of one, folding numbers and moving numeric factors to the front.
+func productNode(a, b *node) *node {
+	x, y := numeric(a), numeric(b)
+	switch {
+	case x != nil && y != nil:
+		return numberNode(new(big.Rat).Mul(x, y))
+
+	case (x != nil && x.Sign() == 0) || (y != nil && y.Sign() == 0):
+		return numberNode(0)
+
+	case x != nil && x.Cmp(big.NewRat(1, 1)) == 0:
+		return b
+
+	case y != nil && y.Cmp(big.NewRat(1, 1)) == 0:
+		return a
+
+	case y != nil: // coefficient first, e.g., 2*x instead of x*2
+		return productNode(b, a)
+
+	case x != nil && b.value == "*" && numeric(b.left) != nil: // combine coefficients, e.g., 2*(3*x) = 6*x
+		return productNode(productNode(a, b.left), b.right)
+
+	}
+
+	return NewNode("*").SetLeft(a).SetRight(b)
+}
+
+// quotientNode creates the node a / b, omitting divisions by one and folding numbers if the division is exact.
+func quotientNode(a, b *node) *node {
+	x, y := numeric(a), numeric(b)
+	switch {
+	case x != nil && x.Sign() == 0:
+		return numberNode(0)
+
+	case y != nil && y.Cmp(big.NewRat(1, 1)) == 0:
+		return a
+
+	case x != nil && y != nil && y.Sign() != 0:
+		if q := new(big.Rat).Quo(x, y); q.IsInt() {
+			return numberNode(q)
+		}
+
+	}
+
+	return NewNode("/").SetLeft(a).SetRight(b)
+}
+
+// sumNode creates the node a + b, omitting additions of zero, folding numbers and combining identical terms.
+func sumNode(a, b *node) *node {
+	switch x, y := numeric(a), numeric(b); {
+	case x != nil && y != nil:
+		return numberNode(new(big.Rat).Add(x, y))
+
+	case x != nil && x.Sign() == 0:
+		return b
+
+	case y != nil && y.Sign() == 0:
+		return a
+
+	case equal(a, b):
+		return productNode(numberNode(2), a)
+
+	}
+
+	return NewNode("+").SetLeft(a).SetRight(b)
+}
+
+// differentiate evaluates the special form diff(expr, x) or diff(expr, x, a), i.e., the derivative of expr
+// with respect to the variable x at the current value of x or at a.
+// Its arguments are linked as a list in n and the expression is not evaluated before it is differentiated.
+// It reports along with the value whether the value is exact, see EvaluateExact.
+func (n *node) differentiate(ctx context.Context, p *parser) (Value, bool, error) {
+	var args []*node
+	for current := n; current != nil; current = current.right {
+		args = append(args, current.left)
+	}
+
+	if len(args) != 2 && len(args) != 3 {
+		return nil, false, fmt.Errorf("diff function requires 2 or 3 arguments")
+	}
+
+	variable := args[1]
+	if variable == nil || !variable.IsLeaf() || numeric(variable) != nil {
+		return nil, false, fmt.Errorf("diff function requires a variable as second argument")
+	}
+
+	derivative, err := Derive(args[0], variable.value)
+	if err != nil {
+		return nil, false, err
+	}
+
+	exact := true
+	if len(args) == 3 {
+		var at Value
+		if at, exact, err = args[2].EvaluateExact(ctx, p); err != nil {
+			return nil, false, err
+		}
+
+		p = p.withVariable(variable.value, at)
+	}
+
+	// the steps of the derivative are not part of the expression, hence they are not observed
+	quiet := *p
+	quiet.observer = nil
+
+	value, isExact, err := derivative.EvaluateExact(ctx, &quiet)
+	return value, exact && isExact, err
+}
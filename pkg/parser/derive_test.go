@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestDerive(t *testing.T) {
+	type args struct {
+		expr     string
+		variable string
+	}
+
+	for _, tt := range []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{"test#1", args{"5", "x"}, "0", false},
+		{"test#2", args{"y", "x"}, "0", false},
+		{"test#3", args{"x", "x"}, "1", false},
+		{"test#4", args{"x^2", "x"}, "2*x", false},
+		{"test#5", args{"x*x", "x"}, "2*x", false},
+		{"test#6", args{"3*x^4 - x + 7", "x"}, "12*x^3 - 1", false},
+		{"test#7", args{"x - x", "x"}, "0", false},
+		{"test#8", args{"x/(x + 1)", "x"}, "1/(x + 1)^2", false},
+		{"test#9", args{"sin(x^2)", "x"}, "2*cos(x^2)*x", false},
+		{"test#10", args{"cos(x)", "x"}, "-sin(x)", false},
+		{"test#11", args{"ln(x)", "x"}, "1/x", false},
+		{"test#12", args{"log(2*x)", "x"}, "2/(2*x*ln(10))", false},
+		{"test#13", args{"√x", "x"}, "1/(2*√x)", false},
+		{"test#14", args{"E^(2*x)", "x"}, "2*E^(2*x)", false},
+		{"test#15", args{"2^x", "x"}, "2^x*ln(2)", false},
+		{"test#16", args{"x^x", "x"}, "x^x*(ln(x) + 1)", false},
+		{"test#17", args{"arctan(y*x)", "y"}, "1/((y*x)^2 + 1)*x", false},
+		{"test#18", args{"x!", "x"}, "", true},
+		{"test#19", args{"max(x, 1)", "x"}, "", true},
+		{"test#20", args{"f(x)", "x"}, "", true},
+		{"test#21", args{"x ± 1", "x"}, "", true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Derive(tree(t, tt.args.expr), tt.args.variable)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Derive(%q, %q) error: %v, want error: %t", tt.args.expr, tt.args.variable, err, tt.wantErr)
+			} else if err == nil && !equal(got.(*node), canonical(tree(t, tt.want))) {
+				t.Errorf("Derive(%q, %q) does not match %q", tt.args.expr, tt.args.variable, tt.want)
+			}
+		})
+	}
+}
+
+func TestExampleFor_ParserWithDiff(t *testing.T) {
+	opts := []Option{
+		WithFunc("sin", math.Sin),
+		WithFunc("cos", math.Cos),
+		WithFunc("ln", math.Log),
+		WithConst("E", math.E),
+		WithVar("x", func() float64 { return 2 }),
+	}
+
+	for _, tt := range []struct {
+		name    string
+		args    string
+		want    string
+		wantErr bool
+	}{
+		{"test#1", "diff(x^3, x)", "12", false},
+		{"test#2", "diff(x^3, x, 3)", "27", false},
+		{"test#3", "diff(t^2 + 3*t, t, 1)", "5", false},
+		{"test#4", "diff(sin(x), x, 0)", "1", false},
+		{"test#5", "diff(E^x, x, 0)", "1", false},
+		{"test#6", "diff(E^2, E, 3)", "6", false},
+		{"test#7", "1 + diff(diff(x^3, x), x, 1)", "7", false},
+		{"test#8", "diff(x^2, x, [1, 2])", "[2, 4]", false},
+		{"test#9", "diff(t^2, t)", "", true},
+		{"test#10", "diff(x^2, 2, 1)", "", true},
+		{"test#11", "diff(x^2)", "", true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewParser(opts...).ParseValue(context.TODO(), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Error parsing expression %q: %v, want error: %t", tt.args, err, tt.wantErr)
+			} else if err == nil && got.Text('g', 10) != tt.want {
+				t.Errorf("Result of %q: %s, want %s", tt.args, got.Text('g', 10), tt.want)
+			}
+		})
+	}
+}
+
+func TestDeriveDeepTree(t *testing.T) {
+	depth := 100000
+	root := tree(t, strings.Repeat("1+(", depth)+"x*x"+strings.Repeat(")", depth))
+
+	got, err := Derive(root, "x")
+	if err != nil || !equal(got.(*node), tree(t, "2*x")) {
+		t.Errorf("Derivative of tree of depth %d: %v (%v), want 2*x", depth, got, err)
+	}
+}
@@ -0,0 +1,706 @@
+package parser
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"github.com/sarumaj/edu-taschenrechner/pkg/runes"
+)
+
+// Dialect is an input syntax of expressions, see WithDialect.
+type Dialect int
+
+const (
+	NativeDialect    Dialect = iota // the syntax of Tokenize, e.g., √(3)*x^2 + sin(PI/6)
+	LaTeXDialect                    // LaTeX math mode, e.g., \frac{1}{2}\cdot\sqrt{3} + \sin(\pi/6)
+	AsciiMathDialect                // AsciiMath and spreadsheet formulas, e.g., 2**3 + SQRT(2) or sqrt 3 xx 2
+)
+
+// latexFunctions are the functions which have a command of their own in LaTeX, e.g., \sin.
+var latexFunctions = map[string]bool{
+	"sin": true, "cos": true, "tan": true, "arcsin": true, "arccos": true, "arctan": true,
+	"ln": true, "log": true, "exp": true, "det": true, "gcd": true, "lcm": true, "max": true, "min": true,
+}
+
+// asciiMathSymbols are the symbols of AsciiMath and spreadsheet formulas which differ from the native syntax.
+// The names are matched case-insensitively, e.g., SQRT(2) is √(2).
+var asciiMathSymbols = map[string]string{
+	"sqrt": "√",
+	"xx":   "*",
+	"pi":   "PI",
+	"e":    "E",
+	"deg":  "°",
+	"oo":   "Inf",
+}
+
+// translator translates an expression given in an input dialect into the tokens of the native syntax,
+// keeping track of the position of each token in the source.
+// Implicit multiplications, e.g., 2x or (a+b)(a-b), are made explicit.
+type translator struct {
+	parser    *parser
+	source    []rune
+	pos       int    // position of the next rune in source
+	tokens    tokens // translated tokens
+	positions []int  // positions of the tokens in source
+	function  bool   // whether the last token is a function name
+	rows      int    // nesting depth of matrix environments
+}
+
+// append appends the token found at the position in source.
+func (t *translator) append(token string, at int) {
+	t.tokens.append(token)
+	t.positions = append(t.positions, at)
+	t.function = false
+}
+
+// at reports whether the source continues with prefix at the current position.
+func (t *translator) at(prefix string) bool {
+	i := t.pos
+	for _, ch := range prefix {
+		if i >= len(t.source) || t.source[i] != ch {
+			return false
+		}
+		i++
+	}
+
+	return true
+}
+
+// emit appends the token found at the position in source,
+// preceded by a multiplication operator if the token starts an operand directly following another operand.
+func (t *translator) emit(token string, at int) {
+	if startsOperand(token) && t.endsOperand() {
+		t.append("*", at)
+	}
+
+	t.append(token, at)
+}
+
+// emitName emits an identifier after substituting the aliases of the parser.
+// If function is set, the identifier is a function name and the next parenthesis encloses its arguments.
+func (t *translator) emitName(name string, function bool, at int) {
+	if alias, ok := t.parser.aliases[name]; ok {
+		for _, token := range alias {
+			t.emit(token, at)
+		}
+	} else {
+		t.emit(name, at)
+	}
+
+	t.function = function
+}
+
+// endsOperand reports whether the last token ends an operand.
+func (t *translator) endsOperand() bool {
+	if t.function || t.tokens.len() == 0 {
+		return false
+	}
+
+	last := t.tokens[t.tokens.len()-1]
+	return isOperand(last) || last == ")" || last == "]" || last == "!" || last == "°"
+}
+
+// errorf returns an error at the position in source.
+func (t *translator) errorf(at int, format string, args ...any) error {
+	return fmt.Errorf("%s at position %d", fmt.Sprintf(format, args...), at+1)
+}
+
+// read reads the runes matching the predicate from the current position on.
+func (t *translator) read(predicate func(rune) bool) string {
+	start := t.pos
+	for t.pos < len(t.source) && predicate(t.source[t.pos]) {
+		t.pos++
+	}
+
+	return string(t.source[start:t.pos])
+}
+
+// skipSpace skips whitespace in source.
+func (t *translator) skipSpace() {
+	for t.pos < len(t.source) && (unicode.IsSpace(t.source[t.pos]) || t.source[t.pos] == '~') {
+		t.pos++
+	}
+}
+
+// symbol emits the native token for a single rune, which is either an operator, a bracket or has an alias.
+func (t *translator) symbol(ch rune, at int) error {
+	switch {
+	case runes.IsAnyOf(ch, "()[],+-±*/@!√^°"):
+		t.emit(string(ch), at)
+
+	case len(t.parser.aliases[string(ch)]) > 0:
+		t.emitName(string(ch), false, at)
+
+	default:
+		return t.errorf(at, "unexpected character %q", ch)
+
+	}
+
+	return nil
+}
+
+// tree parses the translated tokens into a parse tree.
+// Syntax errors are reported at the position in source of the token at which the parsing failed.
+func (t *translator) tree() (Node, error) {
+	if err := exceeded(TokensLimit, t.parser.limits.Tokens, t.tokens.len()); err != nil {
+		return nil, err
+	}
+
+	b := &builder{tokens: t.tokens, maxDepth: t.parser.limits.Depth}
+	position := func() int {
+		if b.pos < len(t.positions) {
+			return t.positions[b.pos]
+		}
+		return len(t.source)
+	}
+
+	root, err := b.build()
+	if err != nil {
+		return nil, fmt.Errorf("%w at position %d", err, position()+1)
+	}
+
+	if b.pos < t.tokens.len() {
+		return nil, t.errorf(position(), "unexpected %q", b.peek())
+	}
+
+	return root, nil
+}
+
+// asciiMath translates the source from AsciiMath or spreadsheet syntax.
+// A leading equal sign of spreadsheet formulas is ignored.
+func (t *translator) asciiMath() error {
+	t.skipSpace()
+	if t.at("=") {
+		t.pos++
+	}
+
+	for t.skipSpace(); t.pos < len(t.source); t.skipSpace() {
+		if err := t.asciiMathElement(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// asciiMathElement translates the next number, name or operator.
+func (t *translator) asciiMathElement() error {
+	start := t.pos
+	switch ch := t.source[t.pos]; {
+	case runes.IsDigit(ch), ch == '.':
+		t.emit(t.read(func(r rune) bool { return runes.IsDigit(r) || r == '.' }), start)
+
+	case runes.InRange(ch, 'a', 'z'), runes.InRange(ch, 'A', 'Z'), ch == '_':
+		return t.asciiMathName(t.read(func(r rune) bool { return isNameRune(r) }), start)
+
+	case t.at("**"): // power in spreadsheet and programming languages
+		t.pos += 2
+		t.append("^", start)
+
+	case t.at("-:"): // division
+		t.pos += 2
+		t.append("/", start)
+
+	case ch == ';': // argument separator in spreadsheets of some locales
+		t.pos++
+		t.append(",", start)
+
+	default:
+		t.pos++
+		return t.symbol(ch, start)
+
+	}
+
+	return nil
+}
+
+// asciiMathName translates a name. Names which are not known to the parser are matched case-insensitively
+// against the names known to the parser, e.g., SIN is sin, and the AsciiMath symbols, e.g., SQRT is √.
+// Functions which are not followed by parentheses take the next element as argument, e.g., sin x.
+func (t *translator) asciiMathName(name string, start int) error {
+	if known, ok := t.parser.fold(name); ok {
+		name = known
+	} else if symbol, ok := asciiMathSymbols[strings.ToLower(name)]; ok {
+		if !isOperand(symbol) { // operators, e.g., xx
+			t.emit(symbol, start)
+			return nil
+		}
+		name = symbol
+	}
+
+	_, function := t.parser.LookupFunc(name)
+	t.emitName(name, function, start)
+
+	t.skipSpace()
+	switch {
+	case !function && t.at("()"): // constants of spreadsheets, e.g., PI()
+		t.pos += 2
+
+	case function && t.pos < len(t.source) && t.source[t.pos] != '(':
+		t.append("(", t.pos)
+		if err := t.asciiMathElement(); err != nil {
+			return err
+		}
+		t.append(")", t.pos)
+
+	case function && t.pos >= len(t.source):
+		return t.errorf(start, "missing argument of function %s", name)
+
+	}
+
+	return nil
+}
+
+// latex translates the source from LaTeX math mode.
+func (t *translator) latex() error {
+	if err := t.latexSequence(0); err != nil {
+		return err
+	}
+
+	if t.pos < len(t.source) {
+		return t.errorf(t.pos, "unexpected %q", t.source[t.pos])
+	}
+
+	return nil
+}
+
+// latexSequence translates elements up to the closing rune or an \end command.
+func (t *translator) latexSequence(closing rune) error {
+	for t.skipSpace(); t.pos < len(t.source) && t.source[t.pos] != closing && !t.at(`\end`); t.skipSpace() {
+		if err := t.latexElement(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// latexElement translates the next number, letter, group, command or operator.
+// Letters are single variables, except for e, which is Euler's number.
+func (t *translator) latexElement() error {
+	start := t.pos
+	switch ch := t.source[t.pos]; {
+	case runes.IsDigit(ch), ch == '.':
+		t.emit(t.read(func(r rune) bool { return runes.IsDigit(r) || r == '.' }), start)
+
+	case runes.InRange(ch, 'a', 'z'), runes.InRange(ch, 'A', 'Z'):
+		t.pos++
+		if ch == 'e' {
+			t.emitName("E", false, start)
+		} else {
+			t.emitName(string(ch), false, start)
+		}
+
+	case ch == '{':
+		return t.latexGroup()
+
+	case ch == '^':
+		t.pos++
+		t.skipSpace()
+		for _, degree := range []string{`{\circ}`, `\circ`, `{\degree}`} {
+			if t.at(degree) {
+				t.pos += len([]rune(degree))
+				t.append("°", start)
+				return nil
+			}
+		}
+
+		t.append("^", start)
+		return t.latexArgument()
+
+	case ch == '_': // subscripts are part of the name, e.g., x_{1} is x_1
+		t.pos++
+		subscript, err := t.latexRaw()
+		if err != nil {
+			return err
+		}
+
+		subscript = strings.ReplaceAll(strings.ReplaceAll(subscript, `\_`, "_"), " ", "")
+		if t.tokens.len() == 0 || !isOperand(t.tokens[t.tokens.len()-1]) || !isName(subscript) {
+			return t.errorf(start, "invalid subscript")
+		}
+
+		t.tokens[t.tokens.len()-1] += "_" + subscript
+
+	case ch == '&':
+		if t.rows == 0 {
+			return t.errorf(start, "column separator outside of matrix")
+		}
+
+		t.pos++
+		t.append(",", start)
+
+	case ch == '\\':
+		return t.latexCommand()
+
+	default:
+		t.pos++
+		return t.symbol(ch, start)
+
+	}
+
+	return nil
+}
+
+// latexArgument translates the argument of a command, either a group or a single digit, letter or command,
+// and encloses it in parentheses.
+func (t *translator) latexArgument() error {
+	t.skipSpace()
+	start := t.pos
+	if t.pos >= len(t.source) {
+		return t.errorf(start, "missing argument")
+	}
+
+	if t.source[t.pos] == '{' {
+		return t.latexGroup()
+	}
+
+	t.emit("(", start)
+	if ch := t.source[t.pos]; runes.IsDigit(ch) { // e.g., x^23 is x^{2}3
+		t.pos++
+		t.append(string(ch), start)
+	} else if err := t.latexElement(); err != nil {
+		return err
+	}
+	t.append(")", start)
+
+	return nil
+}
+
+// latexCommand translates the next command.
+func (t *translator) latexCommand() error {
+	start := t.pos
+	t.pos++ // consume the backslash
+	if t.pos >= len(t.source) {
+		return t.errorf(start, "incomplete command")
+	}
+
+	name := t.read(func(r rune) bool { return runes.InRange(r, 'a', 'z') || runes.InRange(r, 'A', 'Z') })
+	if name == "" { // commands of a single symbol, e.g., \, or \{
+		name = string(t.source[t.pos])
+		t.pos++
+	}
+
+	switch name {
+	case ",", ";", ":", "!", " ", "quad", "qquad": // spacing
+
+	case "left", "right", "bigl", "bigr", "Bigl", "Bigr": // the delimiter follows, \left. is invisible
+		t.skipSpace()
+		if t.at(".") {
+			t.pos++
+		}
+
+	case "{":
+		t.emit("(", start)
+
+	case "}":
+		t.append(")", start)
+
+	case "cdot", "times", "ast":
+		t.append("*", start)
+
+	case "div":
+		t.append("/", start)
+
+	case "pm":
+		t.append("±", start)
+
+	case "pi":
+		t.emitName("PI", false, start)
+
+	case "circ", "degree":
+		t.append("°", start)
+
+	case "infty":
+		t.emit("Inf", start)
+
+	case "frac", "dfrac", "tfrac": // the fraction is a factor of its own, e.g., 2/\frac{1}{2} is 2/(1/2)
+		t.emit("(", start)
+		if err := t.latexArgument(); err != nil {
+			return err
+		}
+		t.append("/", start)
+		if err := t.latexArgument(); err != nil {
+			return err
+		}
+		t.append(")", t.pos)
+
+	case "sqrt":
+		return t.latexRoot(start)
+
+	case "operatorname", "mathrm", "mathit", "text", "textrm":
+		raw, err := t.latexRaw()
+		if err != nil {
+			return err
+		}
+
+		identifier := strings.ReplaceAll(strings.ReplaceAll(raw, `\_`, "_"), " ", "")
+		if !isName(identifier) {
+			return t.errorf(start, "invalid name %q", raw)
+		}
+
+		t.emitName(identifier, name == "operatorname", start)
+
+	case "begin":
+		return t.latexMatrix(start)
+
+	case "\\":
+		if t.rows == 0 {
+			return t.errorf(start, "row separator outside of matrix")
+		}
+
+		t.append("]", start)
+		t.append(",", start)
+		t.append("[", start)
+
+	default:
+		if !latexFunctions[name] {
+			return t.errorf(start, `unknown command \%s`, name)
+		}
+
+		// functions take the next element as argument, unless it is followed by parentheses, e.g., \sin x
+		t.emitName(name, true, start)
+		if t.skipSpace(); t.pos >= len(t.source) {
+			return t.errorf(start, `missing argument of \%s`, name)
+		}
+
+		if !t.at("(") && !t.at(`\left`) {
+			t.append("(", t.pos)
+			if err := t.latexElement(); err != nil {
+				return err
+			}
+			t.append(")", t.pos)
+		}
+
+	}
+
+	return nil
+}
+
+// latexGroup translates a group enclosed in braces into a sub-expression.
+func (t *translator) latexGroup() error {
+	start := t.pos
+	t.pos++ // consume the '{'
+	t.emit("(", start)
+	if err := t.latexSequence('}'); err != nil {
+		return err
+	}
+
+	if t.pos >= len(t.source) || t.source[t.pos] != '}' {
+		return t.errorf(start, "missing closing brace")
+	}
+
+	t.append(")", t.pos)
+	t.pos++
+
+	return nil
+}
+
+// latexMatrix translates the matrix environments matrix, bmatrix, pmatrix and vmatrix into a list of rows.
+// The vertical bars of vmatrix denote the determinant.
+func (t *translator) latexMatrix(start int) error {
+	environment, err := t.latexRaw()
+	if err != nil {
+		return err
+	}
+
+	switch environment {
+	case "matrix", "bmatrix", "pmatrix", "Bmatrix", "smallmatrix":
+
+	case "vmatrix":
+		t.emitName("det", true, start)
+		t.append("(", start)
+
+	default:
+		return t.errorf(start, "unsupported environment %s", environment)
+
+	}
+
+	if t.endsOperand() {
+		t.append("*", start)
+	}
+	t.append("[", start)
+	t.append("[", start)
+
+	t.rows++
+	if err := t.latexSequence(0); err != nil {
+		return err
+	}
+	t.rows--
+
+	if !t.at(`\end`) {
+		return t.errorf(start, `missing \end{%s}`, environment)
+	}
+
+	end := t.pos
+	t.pos += len(`\end`)
+	if closing, err := t.latexRaw(); err != nil || closing != environment {
+		return t.errorf(end, `\begin{%s} ended by \end`, environment)
+	}
+
+	// a row separator at the end of the last row is optional
+	if n := t.tokens.len(); n >= 3 && t.tokens[n-3] == "]" && t.tokens[n-2] == "," && t.tokens[n-1] == "[" {
+		t.tokens, t.positions = t.tokens[:n-3], t.positions[:n-3]
+	}
+
+	t.append("]", end)
+	t.append("]", end)
+	if environment == "vmatrix" {
+		t.append(")", end)
+	}
+
+	return nil
+}
+
+// latexRaw reads the argument of a command without translating it, i.e., the content of a group or a single rune.
+func (t *translator) latexRaw() (string, error) {
+	t.skipSpace()
+	start := t.pos
+	if t.pos >= len(t.source) {
+		return "", t.errorf(start, "missing argument")
+	}
+
+	if t.source[t.pos] != '{' {
+		t.pos++
+		return string(t.source[start]), nil
+	}
+
+	for depth, i := 0, t.pos; i < len(t.source); i++ {
+		switch t.source[i] {
+		case '{':
+			depth++
+
+		case '}':
+			if depth--; depth == 0 {
+				t.pos = i + 1
+				return string(t.source[start+1 : i]), nil
+			}
+
+		}
+	}
+
+	return "", t.errorf(start, "missing closing brace")
+}
+
+// latexRoot translates square roots \sqrt{x} and \sqrt[2]{x} into √(x).
+// Other root indices are rejected, since the calculator raises to integer powers only.
+func (t *translator) latexRoot(start int) error {
+	t.skipSpace()
+	if !t.at("[") {
+		t.emit("√", start)
+		return t.latexArgument()
+	}
+
+	// find the end of the index
+	open, depth := t.pos, 0
+	for ; t.pos < len(t.source); t.pos++ {
+		if t.source[t.pos] == '[' {
+			depth++
+		} else if t.source[t.pos] == ']' {
+			if depth--; depth == 0 {
+				break
+			}
+		}
+	}
+
+	if t.pos >= len(t.source) {
+		return t.errorf(open, "missing closing bracket")
+	}
+
+	if index := strings.TrimSpace(string(t.source[open+1 : t.pos])); index != "2" {
+		return t.errorf(open+1, "unsupported root index %s", index)
+	}
+
+	t.pos++ // consume the ']'
+	t.emit("√", start)
+	return t.latexArgument()
+}
+
+// fold returns the name known to the parser, which equals name or, if there is no such name,
+// equals name case-insensitively. It fails if there is none or several.
+func (p *parser) fold(name string) (string, bool) {
+	if p.knows(name) {
+		return name, true
+	}
+
+	var found []string
+	match := func(known string) {
+		if strings.EqualFold(known, name) {
+			found = append(found, known)
+		}
+	}
+
+	p.visible(func(known string, _ *big.Float, _ func() Value, _ *function) { match(known) })
+
+	if len(found) == 1 {
+		return found[0], true
+	}
+
+	return name, false
+}
+
+// knows reports whether name is a constant, function, variable or alias of the parser.
+func (p *parser) knows(name string) bool {
+	constant, variable := p.binding(name)
+	_, isFunc := p.function(name)
+	_, isAlias := p.aliases[name]
+
+	return constant != nil || variable != nil || isFunc || isAlias
+}
+
+// translate parses the expression given in the dialect of the parser.
+func (p *parser) translate(expr string) (Node, error) {
+	t := &translator{parser: p, source: []rune(expr)}
+
+	var err error
+	switch p.dialect {
+	case LaTeXDialect:
+		err = t.latex()
+
+	case AsciiMathDialect:
+		err = t.asciiMath()
+
+	default:
+		return nil, fmt.Errorf("unsupported dialect: %d", p.dialect)
+
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.tree()
+}
+
+// isName reports whether s is a valid name, e.g., x_1.
+func isName(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if !isNameRune(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isNameRune reports whether r may be part of a name.
+func isNameRune(r rune) bool {
+	return runes.InRange(r, 'a', 'z') || runes.InRange(r, 'A', 'Z') || runes.IsDigit(r) || r == '_'
+}
+
+// isOperand reports whether the token is a number or a name.
+func isOperand(token string) bool {
+	r := []rune(token)
+	return len(r) > 0 && (isNameRune(r[0]) || r[0] == '.')
+}
+
+// startsOperand reports whether the token starts an operand.
+func startsOperand(token string) bool {
+	return isOperand(token) || token == "(" || token == "√"
+}
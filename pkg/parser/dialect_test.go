@@ -0,0 +1,134 @@
+package parser
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestExampleFor_ParserWithDialect(t *testing.T) {
+	opts := []Option{
+		WithFunc("sin", math.Sin),
+		WithFunc("ln", math.Log),
+		WithFunc("sum", func(args ...Value) (Value, error) { return args[0], nil }),
+		WithConst("E", math.E),
+		WithConst("PI", math.Pi),
+		WithVar("ANS", func() float64 { return 2 }),
+		WithReplacements("π", "PI"),
+	}
+
+	type args struct {
+		dialect Dialect
+		expr    string
+	}
+
+	for _, tt := range []struct {
+		name string
+		args args
+		want string // expression in native syntax
+	}{
+		{"test#1", args{LaTeXDialect, `\frac{1}{2}\cdot\sqrt{3}`}, "(1/2)*√(3)"},
+		{"test#2", args{LaTeXDialect, `\sin(\pi/6)`}, "sin(PI/6)"},
+		{"test#3", args{LaTeXDialect, `\sin\left(\frac{\pi}{6}\right)`}, "sin(PI/6)"},
+		{"test#4", args{LaTeXDialect, `2x^{2} + 3xy - 1`}, "2*x^2 + 3*x*y - 1"},
+		{"test#5", args{LaTeXDialect, `x^23`}, "x^2*3"},
+		{"test#6", args{LaTeXDialect, `2 / \frac{1}{x}`}, "2/(1/x)"},
+		{"test#7", args{LaTeXDialect, `\sqrt[2]{8}`}, "√(8)"},
+		{"test#8", args{LaTeXDialect, `(a + b)(a - b)`}, "(a + b)*(a - b)"},
+		{"test#9", args{LaTeXDialect, `e^{x_{1}} \div \mathrm{ANS}`}, "E^x_1/ANS"},
+		{"test#10", args{LaTeXDialect, `\sin x + \ln{2}`}, "sin(x) + ln(2)"},
+		{"test#11", args{LaTeXDialect, `30^{\circ} + 5!`}, "30° + 5!"},
+		{"test#12", args{LaTeXDialect, `\begin{bmatrix} 1 & 2 \\ 3 & 4 \\ \end{bmatrix}`}, "[[1, 2], [3, 4]]"},
+		{"test#13", args{LaTeXDialect, `\begin{vmatrix} a & b \\ c & d \end{vmatrix}`}, "det([[a, b], [c, d]])"},
+		{"test#14", args{LaTeXDialect, `\operatorname{sum}\left(1, 2\right)`}, "sum(1, 2)"},
+		{"test#15", args{AsciiMathDialect, `2**3`}, "2^3"},
+		{"test#16", args{AsciiMathDialect, `=SQRT(2) * PI()`}, "√(2)*PI"},
+		{"test#17", args{AsciiMathDialect, `SIN(pi/6) + Ln(2)`}, "sin(PI/6) + ln(2)"},
+		{"test#18", args{AsciiMathDialect, `sqrt 3 xx 2x -: 4`}, "√3*2*x/4"},
+		{"test#19", args{AsciiMathDialect, `sin x + SUM(1; 2)`}, "sin(x) + sum(1, 2)"},
+		{"test#20", args{AsciiMathDialect, `2π + ans`}, "2*PI + ANS"},
+		{"test#21", args{AsciiMathDialect, `[[1, 2], [3, 4]][2]`}, "[[1, 2], [3, 4]][2]"},
+		{"test#22", args{LaTeXDialect, `1 - \infty`}, "1 - Inf"},
+		{"test#23", args{AsciiMathDialect, `-oo`}, "-Inf"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewParser(append(opts, WithDialect(tt.args.dialect))...).Tree(tt.args.expr)
+			if err != nil {
+				t.Errorf("Error parsing expression %q: %v", tt.args.expr, err)
+				return
+			}
+
+			if want, _ := NewParser(opts...).Tree(tt.want); !equal(got.(*node), want.(*node)) {
+				t.Errorf("Tree of %q: %s, want %s", tt.args.expr, got, want)
+			}
+		})
+	}
+}
+
+func TestExampleFor_ParserWithDialectErrors(t *testing.T) {
+	type args struct {
+		dialect Dialect
+		expr    string
+	}
+
+	for _, tt := range []struct {
+		name string
+		args args
+		want string
+	}{
+		{"test#1", args{LaTeXDialect, `1 + \foo{2}`}, `unknown command \foo at position 5`},
+		{"test#2", args{LaTeXDialect, `\frac{1}{2`}, "missing closing brace at position 9"},
+		{"test#3", args{LaTeXDialect, `1 & 2`}, "column separator outside of matrix at position 3"},
+		{"test#4", args{LaTeXDialect, `\begin{bmatrix} 1 \end{pmatrix}`}, `\begin{bmatrix} ended by \end at position 19`},
+		{"test#5", args{LaTeXDialect, `1 + {2 * }`}, "missing closing parenthesis at position 11"},
+		{"test#6", args{LaTeXDialect, `x_{+}`}, "invalid subscript at position 2"},
+		{"test#7", args{AsciiMathDialect, `2 + 3 $`}, `unexpected character '$' at position 7`},
+		{"test#8", args{AsciiMathDialect, `(1 + 2`}, "missing closing parenthesis at position 7"},
+		{"test#9", args{AsciiMathDialect, `1 + 2)`}, `unexpected ")" at position 6`},
+		{"test#10", args{Dialect(-1), `1`}, "unsupported dialect: -1"},
+		{"test#11", args{LaTeXDialect, `1 + \sqrt[3]{8}`}, "unsupported root index 3 at position 11"},
+		{"test#12", args{LaTeXDialect, `\sqrt[n]{x}`}, "unsupported root index n at position 7"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewParser(WithDialect(tt.args.dialect)).Tree(tt.args.expr)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Error parsing expression %q: %v, want %q", tt.args.expr, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatLaTeXRoundTrip(t *testing.T) {
+	p := NewParser(WithDialect(LaTeXDialect))
+	for _, expr := range []string{
+		"2*x^2 + √(x + 1)",
+		"(1 - x)/(1 + x)^(0 - 2)",
+		"sin(30°)! - ANS_1",
+		"f(x, [1, 2][2])",
+		"[[1, 2], [3, x]]",
+		"x^(1/2) - 0 - E^PI",
+	} {
+		root := tree(t, expr)
+		got, err := p.Tree(Format(root, LaTeX))
+		if err != nil {
+			t.Errorf("Error parsing %q: %v", Format(root, LaTeX), err)
+		} else if !equal(got.(*node), root) {
+			t.Errorf("Tree of %q: %s, want %s", Format(root, LaTeX), got, root)
+		}
+	}
+}
+
+func TestParseValueWithDialect(t *testing.T) {
+	p := NewParser(WithDialect(AsciiMathDialect), WithConst("pi", math.Pi), WithFunc("cos", math.Cos))
+	if got, err := p.Parse(context.TODO(), "=2**3 + COS(PI)"); err != nil || got.Text('g', 10) != "7" {
+		t.Errorf("Result of %q: %v, %v, want 7", "=2**3 + COS(PI)", got, err)
+	}
+
+	p = NewParser(WithDialect(LaTeXDialect))
+	for _, expr := range []string{`\sqrt{9}`, `\sqrt[2]{9}`, `\sqrt[ 2 ]{4 + 5}`} {
+		if got, err := p.Parse(context.TODO(), expr); err != nil || got.Text('g', 10) != "3" {
+			t.Errorf("Result of %q: %v, %v, want 3", expr, got, err)
+		}
+	}
+}
@@ -0,0 +1,494 @@
+package parser
+
+import (
+	"html"
+	"strings"
+)
+
+// Notation is a textual notation of expressions.
+type Notation int
+
+const (
+	Infix   Notation = iota // canonical infix notation, e.g., 2*x^2 + √(x + 1)
+	Unicode                 // display-friendly notation, e.g., 2×x² + √(x + 1)
+	LaTeX                   // LaTeX math mode, e.g., 2 \cdot x^{2} + \sqrt{x + 1}
+	MathML                  // presentation MathML
+)
+
+// precedence levels of the nodes, which correspond to the parsing order of the tokens
+const (
+	additive       = iota + 1 // binary + - ±
+	multiplicative            // * / @
+	prefix                    // unary minus, √ and negative numbers
+	exponential               // ^
+	postfix                   // ! ° and indexing
+	primary                   // numbers, identifiers, function calls, lists
+)
+
+// Format prints the parse tree given by root in the given notation.
+// Parentheses are only inserted where they are needed to retain the structure of the tree,
+// i.e., the infix notation of a parse tree parses to the same tree again.
+// Unary minus is printed as prefix operator, regardless if it is encoded as 0 - x or as "-" node without right operand.
+func Format(root Node, notation Notation) string {
+	n, ok := root.(*node)
+	if !ok || n == nil {
+		return ""
+	}
+
+	p := printer{notation: notation}
+	if notation == MathML {
+		return p.print(concat(text(`<math xmlns="http://www.w3.org/1998/Math/MathML">`), subtree(n), text(`</math>`)))
+	}
+
+	return p.print(subtree(n))
+}
+
+// printer prints parse trees in a notation.
+type printer struct{ notation Notation }
+
+// part is a part of the printed text, either a text or a subtree, which is printed in its place.
+type part struct {
+	text string
+	node *node
+}
+
+// concat concatenates the parts.
+func concat(parts ...[]part) []part {
+	var result []part
+	for _, p := range parts {
+		result = append(result, p...)
+	}
+
+	return result
+}
+
+// text returns the text as parts.
+func text(s string) []part { return []part{{text: s}} }
+
+// subtree returns the subtree n as parts.
+func subtree(n *node) []part { return []part{{node: n}} }
+
+// print prints the parts. The subtrees are replaced by their parts using an explicit stack instead of recursion,
+// so that deeply nested trees are printed in linear time.
+func (p printer) print(parts []part) string {
+	var b strings.Builder
+	stack := make([]part, 0, len(parts))
+	for i := len(parts) - 1; i >= 0; i-- {
+		stack = append(stack, parts[i])
+	}
+
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if top.node == nil {
+			b.WriteString(top.text)
+			continue
+		}
+
+		expanded := p.parts(top.node)
+		for i := len(expanded) - 1; i >= 0; i-- {
+			stack = append(stack, expanded[i])
+		}
+	}
+
+	return b.String()
+}
+
+// parts returns the parts of the subtree n.
+func (p printer) parts(n *node) []part {
+	switch {
+	case n.value == "[": // list literals are leaves if empty
+		return p.list(n)
+
+	case n.IsLeaf():
+		return text(p.leaf(n.value))
+
+	case isNegation(n):
+		operand := operandOf(n)
+		if p.notation == MathML {
+			return concat(text("<mrow>"+p.operator("-")), p.operand(operand, precedence(operand) < prefix || isNegation(operand)), text("</mrow>"))
+		}
+
+		return concat(text(p.operator("-")), p.operand(operand, precedence(operand) < prefix || isNegation(operand)))
+
+	}
+
+	switch n.value {
+	case "+", "-", "±", "*", "/", "@":
+		level := precedence(n)
+		left := p.operand(n.left, precedence(n.left) < level)
+		right := p.operand(n.right, precedence(n.right) <= level || isNegation(n.right))
+
+		switch {
+		case n.value == "/" && p.notation == LaTeX:
+			return concat(text(`\frac{`), subtree(n.left), text("}{"), subtree(n.right), text("}"))
+
+		case n.value == "/" && p.notation == MathML:
+			return concat(text("<mfrac>"), p.row(n.left), p.row(n.right), text("</mfrac>"))
+
+		case p.notation == MathML:
+			return concat(text("<mrow>"), left, text(p.operator(n.value)), right, text("</mrow>"))
+
+		case n.value == "+" || n.value == "-" || n.value == "±":
+			return concat(left, text(" "+p.operator(n.value)+" "), right)
+
+		case p.notation == LaTeX || n.value == "@":
+			return concat(left, text(" "+p.operator(n.value)+" "), right)
+
+		}
+
+		return concat(left, text(p.operator(n.value)), right)
+
+	case "^":
+		base := p.operand(n.left, precedence(n.left) <= exponential)
+
+		switch p.notation {
+		case LaTeX:
+			return concat(base, text("^{"), subtree(n.right), text("}"))
+
+		case MathML:
+			return concat(text("<msup>"), base, p.row(n.right), text("</msup>"))
+
+		case Unicode:
+			if exponent, ok := superscript(n.right); ok {
+				return concat(base, text(exponent))
+			}
+
+		}
+
+		return concat(base, text("^"), p.operand(n.right, precedence(n.right) < exponential || isNegation(n.right)))
+
+	case "√":
+		switch p.notation {
+		case LaTeX:
+			return concat(text(`\sqrt{`), subtree(n.left), text("}"))
+
+		case MathML:
+			return concat(text("<msqrt>"), subtree(n.left), text("</msqrt>"))
+
+		}
+
+		return concat(text("√"), p.operand(n.left, precedence(n.left) < postfix))
+
+	case "!", "°":
+		operand := p.operand(n.left, precedence(n.left) < postfix)
+		switch {
+		case n.value == "°" && p.notation == LaTeX:
+			return concat(operand, text(`^{\circ}`))
+
+		case p.notation == MathML:
+			return concat(text("<mrow>"), operand, text(p.operator(n.value)+"</mrow>"))
+
+		}
+
+		return concat(operand, text(n.value))
+
+	case "[]":
+		operand := p.operand(n.left, precedence(n.left) < postfix)
+		switch p.notation {
+		case LaTeX:
+			return concat(operand, text(`\left[`), subtree(n.right), text(`\right]`))
+
+		case MathML:
+			return concat(text("<mrow>"), operand, text(p.operator("[")), subtree(n.right), text(p.operator("]")+"</mrow>"))
+
+		}
+
+		return concat(operand, text("["), subtree(n.right), text("]"))
+
+	}
+
+	return p.call(n)
+}
+
+// call returns the parts of the function call n.
+func (p printer) call(n *node) []part {
+	switch p.notation {
+	case LaTeX:
+		name := `\operatorname{` + n.value + "}"
+		if latexFunctions[n.value] {
+			name = `\` + n.value
+		}
+
+		return concat(text(name+`\left(`), separated(n.left, ", "), text(`\right)`))
+
+	case MathML:
+		return concat(
+			text("<mrow><mi>"+html.EscapeString(n.value)+"</mi><mo>&#x2061;</mo><mrow>"+p.operator("(")),
+			separated(n.left, p.operator(",")),
+			text(p.operator(")")+"</mrow></mrow>"),
+		)
+
+	}
+
+	return concat(text(n.value+"("), separated(n.left, ", "), text(")"))
+}
+
+// group encloses the parts in parentheses.
+func (p printer) group(parts []part) []part {
+	switch p.notation {
+	case LaTeX:
+		return concat(text(`\left(`), parts, text(`\right)`))
+
+	case MathML:
+		return concat(text("<mrow>"+p.operator("(")), parts, text(p.operator(")")+"</mrow>"))
+
+	}
+
+	return concat(text("("), parts, text(")"))
+}
+
+// infinity prints the infinity, which is negative if sign is set.
+func (p printer) infinity(sign bool) string {
+	var text string
+	switch p.notation {
+	case Unicode:
+		text = "∞"
+
+	case LaTeX:
+		text = `\infty`
+
+	case MathML:
+		text = "<mi>&#x221E;</mi>"
+		if sign {
+			return "<mrow>" + p.operator("-") + text + "</mrow>"
+		}
+
+		return text
+
+	default:
+		text = "Inf"
+
+	}
+
+	if sign {
+		return p.operator("-") + text
+	}
+
+	return text
+}
+
+// leaf prints a number or an identifier.
+func (p printer) leaf(value string) string {
+	x, isNumber := (&node{value: value}).Float()
+	if isNumber && x.IsInf() {
+		return p.infinity(x.Signbit())
+	}
+
+	switch p.notation {
+	case Unicode:
+		switch value {
+		case "PI":
+			return "π"
+
+		case "E":
+			return "e"
+
+		}
+
+	case LaTeX:
+		switch value {
+		case "PI":
+			return `\pi`
+
+		case "E":
+			return "e"
+
+		}
+
+		if !isNumber && len(value) > 1 {
+			return `\mathrm{` + strings.ReplaceAll(value, "_", `\_`) + "}"
+		}
+
+		return value
+
+	case MathML:
+		switch {
+		case value == "PI":
+			return "<mi>&#x03C0;</mi>"
+
+		case value == "E":
+			return "<mi>e</mi>"
+
+		case isNumber && strings.HasPrefix(value, "-"):
+			return "<mrow>" + p.operator("-") + "<mn>" + html.EscapeString(value[1:]) + "</mn></mrow>"
+
+		case isNumber:
+			return "<mn>" + html.EscapeString(value) + "</mn>"
+
+		}
+
+		return "<mi>" + html.EscapeString(value) + "</mi>"
+
+	}
+
+	return value
+}
+
+// list returns the parts of the list literal n, lists of lists are printed as matrices in LaTeX.
+func (p printer) list(n *node) []part {
+	matrix := n.left != nil
+	for current := n.left; current != nil; current = current.right {
+		matrix = matrix && current.left.value == "[" && !current.left.IsLeaf()
+	}
+
+	switch p.notation {
+	case LaTeX:
+		if matrix {
+			parts := text(`\begin{bmatrix} `)
+			for current := n.left; current != nil; current = current.right {
+				if current != n.left {
+					parts = append(parts, text(` \\ `)...)
+				}
+				parts = append(parts, separated(current.left.left, " & ")...)
+			}
+
+			return append(parts, text(` \end{bmatrix}`)...)
+		}
+
+		return concat(text(`\left[`), separated(n.left, ", "), text(`\right]`))
+
+	case MathML:
+		return concat(text("<mrow>"+p.operator("[")), separated(n.left, p.operator(",")), text(p.operator("]")+"</mrow>"))
+
+	}
+
+	return concat(text("["), separated(n.left, ", "), text("]"))
+}
+
+// operand returns the parts of the operand n, enclosed in parentheses if needed.
+func (p printer) operand(n *node, parenthesize bool) []part {
+	if parenthesize {
+		return p.group(subtree(n))
+	}
+
+	return subtree(n)
+}
+
+// operator prints the operator.
+func (p printer) operator(op string) string {
+	switch p.notation {
+	case Unicode:
+		switch op {
+		case "*":
+			return "×"
+
+		case "/":
+			return "÷"
+
+		}
+
+	case LaTeX:
+		switch op {
+		case "*", "@":
+			return `\cdot`
+
+		case "±":
+			return `\pm`
+
+		}
+
+	case MathML:
+		switch op {
+		case "*", "@":
+			return "<mo>&#x22C5;</mo>"
+
+		case "-":
+			return "<mo>&#x2212;</mo>"
+
+		}
+
+		return "<mo>" + html.EscapeString(op) + "</mo>"
+
+	}
+
+	return op
+}
+
+// row returns the parts of the subtree n as a single MathML element.
+func (p printer) row(n *node) []part {
+	return concat(text("<mrow>"), subtree(n), text("</mrow>"))
+}
+
+// separated returns the parts of the items of the nodes linked as a list, e.g., the arguments of a function call,
+// separated by the separator.
+func separated(n *node, separator string) []part {
+	var parts []part
+	for current := n; current != nil; current = current.right {
+		if current != n {
+			parts = append(parts, text(separator)...)
+		}
+		parts = append(parts, subtree(current.left)...)
+	}
+
+	return parts
+}
+
+// isNegation reports whether n is a unary minus, either encoded as 0 - x or as "-" node without right operand,
+// or a negative number.
+func isNegation(n *node) bool {
+	if n.IsLeaf() {
+		x, ok := n.Float()
+		return ok && x.Signbit()
+	}
+
+	return n.value == "-" && (n.right == nil || (n.left.IsLeaf() && n.left.value == "0"))
+}
+
+// operandOf returns the operand of the unary minus n.
+func operandOf(n *node) *node {
+	if n.right == nil {
+		return n.left
+	}
+
+	return n.right
+}
+
+// precedence returns the precedence level of the node n.
+func precedence(n *node) int {
+	switch {
+	case n.IsLeaf():
+		if isNegation(n) {
+			return prefix
+		}
+
+		return primary
+
+	case isNegation(n):
+		return prefix
+
+	}
+
+	switch n.value {
+	case "+", "-", "±":
+		return additive
+
+	case "*", "/", "@":
+		return multiplicative
+
+	case "√":
+		return prefix
+
+	case "^":
+		return exponential
+
+	case "!", "°", "[]":
+		return postfix
+
+	}
+
+	return primary
+}
+
+// superscript converts the integer exponent n to superscript digits, e.g., -12 to ⁻¹².
+func superscript(n *node) (string, bool) {
+	x := numeric(n)
+	if x == nil || !x.IsInt() {
+		return "", false
+	}
+
+	return strings.NewReplacer(
+		"-", "⁻", "0", "⁰", "1", "¹", "2", "²", "3", "³", "4", "⁴",
+		"5", "⁵", "6", "⁶", "7", "⁷", "8", "⁸", "9", "⁹",
+	).Replace(x.Num().String()), true
+}
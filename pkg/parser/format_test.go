@@ -0,0 +1,121 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	type want struct {
+		infix   string
+		unicode string
+		latex   string
+	}
+
+	for _, tt := range []struct {
+		name string
+		args string
+		want want
+	}{
+		{"test#1", "((1 + 2)) * 3", want{"(1 + 2)*3", "(1 + 2)×3", `\left(1 + 2\right) \cdot 3`}},
+		{"test#2", "1 + (2 * 3)", want{"1 + 2*3", "1 + 2×3", `1 + 2 \cdot 3`}},
+		{"test#3", "1 - (2 - 3)", want{"1 - (2 - 3)", "1 - (2 - 3)", `1 - \left(2 - 3\right)`}},
+		{"test#4", "(1 - 2) - 3", want{"1 - 2 - 3", "1 - 2 - 3", `1 - 2 - 3`}},
+		{"test#5", "x / (y * 2)", want{"x/(y*2)", "x÷(y×2)", `\frac{x}{y \cdot 2}`}},
+		{"test#6", "(x + 1) / 2", want{"(x + 1)/2", "(x + 1)÷2", `\frac{x + 1}{2}`}},
+		{"test#7", "x ^ 2", want{"x^2", "x²", `x^{2}`}},
+		{"test#8", "x ^ (y ^ 2)", want{"x^y^2", "x^y²", `x^{y^{2}}`}},
+		{"test#9", "(x ^ y) ^ 2", want{"(x^y)^2", "(x^y)²", `\left(x^{y}\right)^{2}`}},
+		{"test#10", "x ^ (1 + y)", want{"x^(1 + y)", "x^(1 + y)", `x^{1 + y}`}},
+		{"test#11", "-x + 1", want{"-x + 1", "-x + 1", `-x + 1`}},
+		{"test#12", "2 * -x", want{"2*(-x)", "2×(-x)", `2 \cdot \left(-x\right)`}},
+		{"test#13", "(-2) ^ 2", want{"(-2)^2", "(-2)²", `\left(-2\right)^{2}`}},
+		{"test#14", "-x ^ 2", want{"-x^2", "-x²", `-x^{2}`}},
+		{"test#15", "x ^ -1", want{"x^(-1)", "x^(-1)", `x^{-1}`}},
+		{"test#16", "√(x + 1) * √x", want{"√(x + 1)*√x", "√(x + 1)×√x", `\sqrt{x + 1} \cdot \sqrt{x}`}},
+		{"test#17", "√(x ^ 2)", want{"√(x^2)", "√(x²)", `\sqrt{x^{2}}`}},
+		{"test#18", "(√x) ^ 2", want{"(√x)^2", "(√x)²", `\left(\sqrt{x}\right)^{2}`}},
+		{"test#19", "(x + 1)! + 3!°", want{"(x + 1)! + 3!°", "(x + 1)! + 3!°", `\left(x + 1\right)! + 3!^{\circ}`}},
+		{"test#20", "sin(PI * x) + f(1, E)", want{"sin(PI*x) + f(1, E)", "sin(π×x) + f(1, e)", `\sin\left(\pi \cdot x\right) + \operatorname{f}\left(1, e\right)`}},
+		{"test#21", "[1, x][2] + len([])", want{"[1, x][2] + len([])", "[1, x][2] + len([])", `\left[1, x\right]\left[2\right] + \operatorname{len}\left(\left[\right]\right)`}},
+		{"test#22", "[[1, 2], [3, 4]] @ v", want{"[[1, 2], [3, 4]] @ v", "[[1, 2], [3, 4]] @ v", `\begin{bmatrix} 1 & 2 \\ 3 & 4 \end{bmatrix} \cdot v`}},
+		{"test#23", "ANS * 2", want{"ANS*2", "ANS×2", `\mathrm{ANS} \cdot 2`}},
+		{"test#24", "1 / Inf - Inf", want{"1/Inf - Inf", "1÷∞ - ∞", `\frac{1}{\infty} - \infty`}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			root := tree(t, tt.args)
+			for notation, want := range map[Notation]string{Infix: tt.want.infix, Unicode: tt.want.unicode, LaTeX: tt.want.latex} {
+				if got := Format(root, notation); got != want {
+					t.Errorf("Format(%q, %d) = %q, want %q", tt.args, notation, got, want)
+				}
+			}
+
+			// the infix notation must parse to the same tree
+			if got := tree(t, root.String()); !equal(got, root) {
+				t.Errorf("Parse tree of %q differs from the tree of %q", root.String(), tt.args)
+			}
+		})
+	}
+}
+
+func TestFormatMathML(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		args string
+		want string
+	}{
+		{"test#1", "2 * x", "<mrow><mn>2</mn><mo>&#x22C5;</mo><mi>x</mi></mrow>"},
+		{"test#2", "1 / (x - 1)", "<mfrac><mrow><mn>1</mn></mrow><mrow><mrow><mi>x</mi><mo>&#x2212;</mo><mn>1</mn></mrow></mrow></mfrac>"},
+		{"test#3", "√x ^ 2", "<msqrt><msup><mi>x</mi><mrow><mn>2</mn></mrow></msup></msqrt>"},
+		{"test#4", "sin(PI)", "<mrow><mi>sin</mi><mo>&#x2061;</mo><mrow><mo>(</mo><mi>&#x03C0;</mi><mo>)</mo></mrow></mrow>"},
+		{"test#5", "-a", "<mrow><mo>&#x2212;</mo><mi>a</mi></mrow>"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			want := `<math xmlns="http://www.w3.org/1998/Math/MathML">` + tt.want + "</math>"
+			if got := Format(tree(t, tt.args), MathML); got != want {
+				t.Errorf("Format(%q, MathML) = %q, want %q", tt.args, got, want)
+			}
+		})
+	}
+}
+
+func TestFormatSimplified(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		args string
+		want string
+	}{
+		{"test#1", "0 - x*x", "-x^2"},
+		{"test#2", "x^(0 - 1) * 3", "3*x^(-1)"},
+		{"test#3", "1 - 2*x", "-2*x + 1"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Simplify(tree(t, tt.args)).String(); got != tt.want {
+				t.Errorf("Simplify(%q).String() = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDeepTree(t *testing.T) {
+	depth := 100000
+	root := tree(t, strings.Repeat("1+(", depth)+"x"+strings.Repeat(")", depth))
+
+	for _, tt := range []struct {
+		name                  string
+		notation              Notation
+		prefix, inner, suffix string
+	}{
+		{"test#1", Infix, "1 + (", "1 + x", ")"},
+		{"test#2", Unicode, "1 + (", "1 + x", ")"},
+		{"test#3", LaTeX, `1 + \left(`, "1 + x", `\right)`},
+		{"test#4", MathML, "<mrow><mn>1</mn><mo>+</mo><mrow><mo>(</mo>", "<mrow><mn>1</mn><mo>+</mo><mi>x</mi></mrow>", "<mo>)</mo></mrow></mrow>"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			want := strings.Repeat(tt.prefix, depth-1) + tt.inner + strings.Repeat(tt.suffix, depth-1)
+			if got := strings.TrimSuffix(strings.TrimPrefix(Format(root, tt.notation), `<math xmlns="http://www.w3.org/1998/Math/MathML">`), "</math>"); got != want {
+				t.Errorf("Notation of tree of depth %d: %.40s..., want %.40s...", depth, got, want)
+			}
+		})
+	}
+}
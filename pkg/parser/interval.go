@@ -0,0 +1,598 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Interval is a closed interval [Lo, Hi] enclosing a result, e.g., of [9.8, 9.81] * [1.9, 2.1] or 9.81 ± 0.01,
+// see WithIntervals. Its bounds are rounded outwards, i.e., the lower bound down and the upper bound up,
+// hence the exact result is guaranteed to lie within the interval.
+type Interval struct {
+	Lo, Hi *big.Float
+}
+
+// Text formats the interval like a list literal of its bounds, e.g., [18.62, 20.601], using the given format and precision.
+// The bounds are rounded outwards to the printed digits, hence the printed interval encloses the interval.
+func (x Interval) Text(format byte, prec int) string {
+	return "[" + bound(x.Lo, format, prec, false) + ", " + bound(x.Hi, format, prec, true) + "]"
+}
+
+// value returns the interval, or its lower bound if the interval is degenerate, i.e., if the result is exact.
+func (x Interval) value() Value {
+	if x.Lo.Cmp(x.Hi) == 0 {
+		value := new(big.Float).SetPrec(x.Lo.Prec()).Set(x.Lo) // rounded to the nearest by further operations
+		if value.Sign() == 0 {
+			value.Abs(value) // 1 - 1 is rounded down to -0
+		}
+
+		return value
+	}
+
+	return x
+}
+
+// standard are the interval versions of the standard functions, see WithIntervals.
+// The functions of package math are accurate to about one ulp, hence their results are widened by two ulps.
+var standard = map[string]func(Interval) (Interval, error){
+	"abs":    Interval.abs,
+	"arccos": monotonic("arccos", math.Acos, -1, 1, false),
+	"arcsin": monotonic("arcsin", math.Asin, -1, 1, true),
+	"arctan": monotonic("arctan", math.Atan, math.Inf(-1), math.Inf(1), true),
+	"cos":    periodic(math.Cos, 0),
+	"exp":    monotonic("exp", exp, math.Inf(-1), math.Inf(1), true),
+	"ln":     monotonic("ln", math.Log, 0, math.Inf(1), true),
+	"log":    monotonic("log", math.Log10, 0, math.Inf(1), true),
+	"sin":    periodic(math.Sin, math.Pi/2),
+	"sqrt":   Interval.sqrt,
+	"tan":    Interval.tan,
+}
+
+// radian encloses the angle of one degree in radians, π/180.
+// The float64 value of π is less than π and its successor greater.
+var radian, _ = Interval{big.NewFloat(math.Pi), big.NewFloat(math.Nextafter(math.Pi, 4))}.quo(Interval{big.NewFloat(180), big.NewFloat(180)})
+
+// enclose returns the interval enclosing the element of a list, i.e., the interval itself or the degenerate interval of a scalar.
+func enclose(value Value) (Interval, error) {
+	if x, ok := value.(Interval); ok {
+		return x, nil
+	}
+
+	x, err := scalar(value)
+	if err != nil {
+		return Interval{}, err
+	}
+
+	return Interval{x, x}, nil
+}
+
+// enclosed reports whether interval arithmetic applies to the values,
+// i.e., whether it is enabled or any of the values holds an interval.
+func (p *parser) enclosed(values ...Value) bool {
+	if p.intervals {
+		return true
+	}
+
+	for _, value := range values {
+		switch value := value.(type) {
+		case Interval:
+			return true
+
+		case List:
+			if p.enclosed(value...) {
+				return true
+			}
+
+		}
+	}
+
+	return false
+}
+
+// elementwise applies the operator to every element of value like apply,
+// using interval arithmetic for the elements to which it applies, see enclosed.
+func (p *parser) elementwise(value Value, fn func(*big.Float) (Value, error), op func(Interval) (Interval, error)) (Value, error) {
+	return each(value, func(element Value) (Value, error) {
+		if !p.enclosed(element) {
+			x, err := scalar(element)
+			if err != nil {
+				return nil, err
+			}
+
+			return fn(x)
+		}
+
+		x, err := enclose(element)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := op(x)
+		if err != nil {
+			return nil, err
+		}
+
+		return result.value(), nil
+	})
+}
+
+// arithmetic applies the operator element-wise to left and right like broadcast,
+// using interval arithmetic for the pairs of elements to which it applies, see enclosed.
+func (p *parser) arithmetic(left, right Value, fn func(x, y *big.Float) (Value, error), op func(x, y Interval) (Interval, error)) (Value, error) {
+	return combine(left, right, func(left, right Value) (Value, error) {
+		if !p.enclosed(left, right) {
+			x, err := scalar(left)
+			if err != nil {
+				return nil, err
+			}
+
+			y, err := scalar(right)
+			if err != nil {
+				return nil, err
+			}
+
+			return fn(x, y)
+		}
+
+		x, err := enclose(left)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := enclose(right)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := op(x, y)
+		if err != nil {
+			return nil, err
+		}
+
+		return result.value(), nil
+	})
+}
+
+// literal returns the interval enclosing the number, e.g., 9.8, which is not representable exactly in binary,
+// or the number itself if it is representable.
+func literal(number string) (Value, bool) {
+	lo, _, err := big.ParseFloat(number, 0, 64, big.ToNegativeInf)
+	if err != nil {
+		return nil, false
+	}
+
+	hi, _, err := big.ParseFloat(number, 0, 64, big.ToPositiveInf)
+	if err != nil {
+		return nil, false
+	}
+
+	return Interval{lo, hi}.value(), true
+}
+
+// widen returns the value with its scalars widened by one ulp on both sides,
+// which encloses the exact value of an approximate constant rounded to its precision, e.g., of π.
+func widen(value Value) Value {
+	widened, _ := each(value, func(element Value) (Value, error) {
+		x, ok := element.(*big.Float)
+		if !ok || x == nil || x.Sign() == 0 || x.IsInf() {
+			return element, nil
+		}
+
+		return Interval{rounded(false).Sub(x, ulp(x)), rounded(true).Add(x, ulp(x))}, nil
+	})
+
+	return widened
+}
+
+// hull returns the interval spanned by the lower bound of lo and the upper bound of hi, e.g., by the list literal [9.8, 9.81].
+func hull(lo, hi Value) (Value, error) {
+	x, err := enclose(lo)
+	if err != nil {
+		return nil, err
+	}
+
+	y, err := enclose(hi)
+	if err != nil {
+		return nil, err
+	}
+
+	if x.Lo.Cmp(y.Hi) > 0 {
+		return nil, fmt.Errorf("lower bound %s exceeds upper bound %s", x.Lo.Text('g', 10), y.Hi.Text('g', 10))
+	}
+
+	return Interval{x.Lo, y.Hi}.value(), nil
+}
+
+// rounded returns a number to be set to the result of an operation, which is rounded down or up.
+// The precision of the result is the one of the operands, see big.Float.
+func rounded(up bool) *big.Float {
+	if up {
+		return new(big.Float).SetMode(big.ToPositiveInf)
+	}
+
+	return new(big.Float).SetMode(big.ToNegativeInf)
+}
+
+// add returns the interval enclosing x + y.
+func (x Interval) add(y Interval) (Interval, error) {
+	return Interval{rounded(false).Add(x.Lo, y.Lo), rounded(true).Add(x.Hi, y.Hi)}, nil
+}
+
+// sub returns the interval enclosing x - y.
+func (x Interval) sub(y Interval) (Interval, error) {
+	return Interval{rounded(false).Sub(x.Lo, y.Hi), rounded(true).Sub(x.Hi, y.Lo)}, nil
+}
+
+// mul returns the interval enclosing x * y.
+func (x Interval) mul(y Interval) (Interval, error) {
+	return extremes(x, y, func(a, b *big.Float, up bool) *big.Float { return rounded(up).Mul(a, b) }), nil
+}
+
+// quo returns the interval enclosing x / y, it fails if y contains zero.
+func (x Interval) quo(y Interval) (Interval, error) {
+	switch {
+	case y.Lo.Sign() == 0 && y.Hi.Sign() == 0:
+		return Interval{}, fmt.Errorf("division by zero")
+
+	case y.Lo.Sign() <= 0 && y.Hi.Sign() >= 0:
+		return Interval{}, fmt.Errorf("division by an interval containing zero")
+
+	}
+
+	return extremes(x, y, func(a, b *big.Float, up bool) *big.Float { return rounded(up).Quo(a, b) }), nil
+}
+
+// tolerance returns the interval x ± y, i.e., x widened by the tolerance y on both sides.
+func (x Interval) tolerance(y Interval) (Interval, error) {
+	if y.Lo.Sign() < 0 {
+		return Interval{}, fmt.Errorf("negative tolerance: %s", y.Lo.Text('g', 10))
+	}
+
+	return Interval{rounded(false).Sub(x.Lo, y.Hi), rounded(true).Add(x.Hi, y.Hi)}, nil
+}
+
+// neg returns the interval -x, which is exact.
+func (x Interval) neg() Interval {
+	return Interval{new(big.Float).Neg(x.Hi), new(big.Float).Neg(x.Lo)}
+}
+
+// abs returns the interval enclosing |x|.
+func (x Interval) abs() (Interval, error) {
+	switch {
+	case x.Lo.Sign() >= 0:
+		return x, nil
+
+	case x.Hi.Sign() <= 0:
+		return x.neg(), nil
+
+	}
+
+	hi := new(big.Float).Neg(x.Lo)
+	if x.Hi.Cmp(hi) > 0 {
+		hi = x.Hi
+	}
+
+	return Interval{big.NewFloat(0), hi}, nil
+}
+
+// sqrt returns the interval enclosing √x, it fails if x contains negative numbers.
+func (x Interval) sqrt() (Interval, error) {
+	if x.Lo.Sign() < 0 {
+		return Interval{}, fmt.Errorf("square root of a negative number")
+	}
+
+	return Interval{root(x.Lo, false), root(x.Hi, true)}, nil
+}
+
+// pow returns the interval enclosing x^y.
+// Unless the exponent is an integer, the base must not contain negative numbers, like for the powers of numbers, see calc.Pow.
+func (x Interval) pow(y Interval) (Interval, error) {
+	if n, accuracy := y.Lo.Int64(); y.Lo.Cmp(y.Hi) == 0 && accuracy == big.Exact {
+		return x.powInt(n)
+	}
+
+	switch {
+	case x.Lo.Sign() > 0: // x^y = exp(y ln x)
+		ln, err := standard["ln"](x)
+		if err != nil {
+			return Interval{}, err
+		}
+
+		product, err := y.mul(ln)
+		if err != nil {
+			return Interval{}, err
+		}
+
+		return standard["exp"](product)
+
+	case x.Lo.Sign() == 0 && y.Lo.Sign() > 0: // 0^y = 0
+		if x.Hi.Sign() == 0 {
+			return x, nil
+		}
+
+		power, err := Interval{x.Hi, x.Hi}.pow(y)
+		return Interval{big.NewFloat(0), power.Hi}, err
+
+	}
+
+	return Interval{}, fmt.Errorf("non-integer power of an interval containing negative numbers")
+}
+
+// powInt returns the interval enclosing x^n for the integer n.
+func (x Interval) powInt(n int64) (Interval, error) {
+	if n < 0 {
+		power, err := x.powInt(-(n + 1)) // x^n = 1/(x^(-n-1) * x) avoids overflowing -n
+		if err != nil {
+			return Interval{}, err
+		}
+
+		if power, err = power.mul(x); err != nil {
+			return Interval{}, err
+		}
+
+		one := big.NewFloat(1)
+		return Interval{one, one}.quo(power)
+	}
+
+	switch {
+	case n%2 == 1 || x.Lo.Sign() >= 0: // increasing
+		return Interval{power(x.Lo, n, false), power(x.Hi, n, true)}, nil
+
+	case x.Hi.Sign() <= 0: // even power, decreasing
+		return Interval{power(x.Hi, n, false), power(x.Lo, n, true)}, nil
+
+	}
+
+	// even power of an interval containing zero
+	lo, hi := power(x.Lo, n, true), power(x.Hi, n, true)
+	if lo.Cmp(hi) > 0 {
+		hi = lo
+	}
+
+	return Interval{big.NewFloat(0), hi}, nil
+}
+
+// tan returns the interval enclosing tan(x), it fails if x contains a pole.
+func (x Interval) tan() (Interval, error) {
+	lo, hi := directed(x.Lo, false), directed(x.Hi, true)
+	if hi-lo >= math.Pi || math.Abs(lo) > 1<<50 || math.Abs(hi) > 1<<50 || attains(lo, hi, math.Pi/2) || attains(lo, hi, -math.Pi/2) {
+		return Interval{}, fmt.Errorf("tan(%s) is undefined", x.Text('g', 10))
+	}
+
+	return Interval{big.NewFloat(widened(math.Tan(lo), false)), big.NewFloat(widened(math.Tan(hi), true))}, nil
+}
+
+// root returns the square root of x rounded down or up.
+// The square roots of math/big are not rounded reliably in the direction of rounding, hence the root is corrected
+// by a unit in the last place until its square, which is computed exactly at twice its precision, confirms the direction.
+func root(x *big.Float, up bool) *big.Float {
+	r := rounded(up).Sqrt(x)
+	if r.Sign() == 0 || r.IsInf() {
+		return r
+	}
+
+	for {
+		order := new(big.Float).SetPrec(2*r.Prec()).Mul(r, r).Cmp(x)
+		switch {
+		case up && order < 0:
+			r.Add(r, ulp(r))
+
+		case !up && order > 0:
+			r.Sub(r, ulp(r))
+
+		default:
+			return r
+
+		}
+	}
+}
+
+// ulp returns the unit in the last place of the finite non-zero x, i.e., the distance to its successor in magnitude.
+func ulp(x *big.Float) *big.Float {
+	return new(big.Float).SetMantExp(big.NewFloat(1), x.MantExp(nil)-int(x.Prec()))
+}
+
+// extremes returns the interval spanned by the results of the operation applied to the bounds of x and y,
+// which are rounded down for the lower bound and up for the upper one.
+// The interval encloses the results for all numbers of x and y if the operation is monotonic in each operand, e.g., a product.
+func extremes(x, y Interval, op func(a, b *big.Float, up bool) *big.Float) Interval {
+	var result Interval
+	for _, a := range []*big.Float{x.Lo, x.Hi} {
+		for _, b := range []*big.Float{y.Lo, y.Hi} {
+			if lo := op(a, b, false); result.Lo == nil || lo.Cmp(result.Lo) < 0 {
+				result.Lo = lo
+			}
+
+			if hi := op(a, b, true); result.Hi == nil || hi.Cmp(result.Hi) > 0 {
+				result.Hi = hi
+			}
+		}
+	}
+
+	return result
+}
+
+// power returns x raised to the non-negative integer n rounded down or up, computed by repeated squaring.
+// The magnitudes of the intermediate results are rounded in the same direction, which bounds the magnitude of the power.
+func power(x *big.Float, n int64, up bool) *big.Float {
+	negative := x.Sign() < 0 && n%2 == 1
+	magnitude := up != negative // whether the magnitude is rounded up
+
+	result := rounded(magnitude).SetPrec(x.Prec()).SetInt64(1)
+	base := rounded(magnitude).SetPrec(x.Prec()).Abs(x)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result.Mul(result, base)
+		}
+
+		if n > 1 {
+			base.Mul(base, base)
+		}
+	}
+
+	if negative {
+		result.Neg(result)
+	}
+
+	return result
+}
+
+// monotonic returns the interval version of the increasing or decreasing function fn defined on [from, to].
+func monotonic(name string, fn func(float64) float64, from, to float64, increasing bool) func(Interval) (Interval, error) {
+	return func(x Interval) (Interval, error) {
+		lo, hi := directed(x.Lo, false), directed(x.Hi, true)
+		if lo < from || hi > to {
+			return Interval{}, fmt.Errorf("%s(%s) is undefined", name, x.Text('g', 10))
+		}
+
+		if !increasing {
+			lo, hi = hi, lo
+		}
+
+		return Interval{big.NewFloat(widened(fn(lo), false)), big.NewFloat(widened(fn(hi), true))}, nil
+	}
+}
+
+// periodic returns the interval version of a function of period 2π like sine, which attains its maximum 1 at the peak
+// and its minimum -1 at the peak + π.
+func periodic(fn func(float64) float64, peak float64) func(Interval) (Interval, error) {
+	return func(x Interval) (Interval, error) {
+		lo, hi := directed(x.Lo, false), directed(x.Hi, true)
+		if hi-lo >= 2*math.Pi || math.Abs(lo) > 1<<50 || math.Abs(hi) > 1<<50 { // the period is not resolved anymore
+			return Interval{big.NewFloat(-1), big.NewFloat(1)}, nil
+		}
+
+		a, b := fn(lo), fn(hi)
+		result := Interval{big.NewFloat(math.Max(-1, widened(math.Min(a, b), false))), big.NewFloat(math.Min(1, widened(math.Max(a, b), true)))}
+		if attains(lo, hi, peak) {
+			result.Hi = big.NewFloat(1)
+		}
+
+		if attains(lo, hi, peak+math.Pi) {
+			result.Lo = big.NewFloat(-1)
+		}
+
+		return result, nil
+	}
+}
+
+// attains reports whether [lo, hi] may contain phase + 2kπ for any integer k.
+// Doubtful cases are reported as contained, which widens the result instead of breaking the enclosure.
+func attains(lo, hi, phase float64) bool {
+	const slack = 1e-9
+	return math.Floor((hi-phase)/(2*math.Pi)+slack) >= math.Ceil((lo-phase)/(2*math.Pi)-slack)
+}
+
+// exp is math.Exp, whose results underflowing to zero are replaced by the smallest positive number, see widened.
+func exp(x float64) float64 {
+	if y := math.Exp(x); y > 0 || math.IsInf(x, -1) {
+		return y
+	}
+
+	return math.SmallestNonzeroFloat64
+}
+
+// directed converts the bound to a float64 rounded down or up.
+func directed(x *big.Float, up bool) float64 {
+	f, accuracy := x.Float64()
+	switch {
+	case up && accuracy == big.Below:
+		return math.Nextafter(f, math.Inf(1))
+
+	case !up && accuracy == big.Above:
+		return math.Nextafter(f, math.Inf(-1))
+
+	}
+
+	return f
+}
+
+// widened returns f moved by two ulps downwards or upwards.
+// Results of zero are exact, since the standard functions vanish at exact arguments only, e.g., sin(0) or ln(1), see exp.
+func widened(f float64, up bool) float64 {
+	if f == 0 {
+		return 0
+	}
+
+	direction := math.Inf(-1)
+	if up {
+		direction = math.Inf(1)
+	}
+
+	return math.Nextafter(math.Nextafter(f, direction), direction)
+}
+
+// bound formats the bound like its Text method, but rounds it down or up to the printed digits instead of to the nearest,
+// e.g., 1/3 as 0.3333 or 0.3334 with 4 digits. Formats other than the decimal ones, e.g., 'p', are printed unchanged.
+func bound(x *big.Float, format byte, prec int, up bool) string {
+	if x.Sign() == 0 {
+		x = new(big.Float).Abs(x) // -0
+	}
+
+	text := x.Text(format, prec)
+	if x.IsInf() || !strings.ContainsRune("eEfgG", rune(format)) {
+		return text
+	}
+
+	if prec < 0 { // the fewest digits, which are rounded back to the bound in the opposite direction
+		mode := big.ToPositiveInf
+		if up {
+			mode = big.ToNegativeInf
+		}
+
+		for prec = 0; ; prec++ {
+			text = bound(x, format, prec, up)
+			if parsed, _, err := big.ParseFloat(text, 10, x.Prec(), mode); err != nil || parsed.Cmp(x) == 0 {
+				return text
+			}
+		}
+	}
+
+	printed, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return text
+	}
+
+	exact, _ := x.Rat(nil)
+	if order := printed.Cmp(exact); order == 0 || (order > 0) == up {
+		return text
+	}
+
+	// step to the adjacent number of the printed digits, e.g., from 0.3333 to 0.3334
+	mantissa, exponent, _ := strings.Cut(strings.ToLower(text), "e")
+	e, _ := strconv.Atoi(exponent)
+	integer, fraction, _ := strings.Cut(strings.TrimPrefix(mantissa, "-"), ".")
+	significant := strings.TrimLeft(integer+fraction, "0")
+
+	// exponent of the last printed digit, trailing zeros are not printed in the 'g' format
+	shift := e - len(fraction)
+	if format == 'g' || format == 'G' {
+		shift = e + len(integer) - len(integer+fraction) + len(significant) - max(prec, 1)
+	}
+
+	quantum := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(shift))), nil))
+	if shift < 0 {
+		quantum.Inv(quantum)
+	}
+
+	if up {
+		printed.Add(printed, quantum)
+	} else {
+		printed.Sub(printed, quantum)
+	}
+
+	stepped := new(big.Float).SetPrec(uint(4*(len(integer+fraction)+prec) + 64)).SetRat(printed)
+	return stepped.Text(format, prec)
+}
+
+// abs returns the absolute value of the integer n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
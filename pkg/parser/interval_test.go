@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestExampleFor_ParserWithIntervals(t *testing.T) {
+	p := NewParser(
+		WithConst("PI", math.Pi),
+		WithApproximate("PI"),
+		WithVar("x", func() float64 { return 2 }),
+		WithIntervals(),
+	)
+
+	for _, tt := range []struct {
+		name  string
+		args  string
+		want  string
+		exact bool
+	}{
+		{"test#1", "[9.8, 9.81] * [1.9, 2.1]", "[18.61999999, 20.60100001]", false},
+		{"test#2", "10 ± 0.5", "[9.5, 10.5]", false},
+		{"test#3", "[-2, 3]^2", "[0, 9]", false},
+		{"test#4", "sqrt([4, 9])", "[2, 3]", false},
+		{"test#5", "2 + 2", "4", true},
+		{"test#6", "1/3", "[0.3333333333, 0.3333333334]", false},
+		{"test#7", "[1, 2] - [1, 2]", "[-1, 1]", false},
+		{"test#8", "[[1, 2], [3, 4]]", "[[1, 2], [3, 4]]", false},
+		{"test#9", "x * (1 ± 0)", "2", true},
+		{"test#10", "cos([0, PI])", "[-1, 1]", false},
+		{"test#11", "abs([-3, 2])", "[0, 3]", false},
+		{"test#12", "-[1, 2]", "[-2, -1]", false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, exact, err := p.ParseExact(context.TODO(), tt.args)
+			switch {
+			case err != nil:
+				t.Errorf("Error parsing expression %q: %v", tt.args, err)
+
+			case got.Text('g', 10) != tt.want || exact != tt.exact:
+				t.Errorf("Result of %q: %s (exact: %t), want %s (exact: %t)", tt.args, got.Text('g', 10), exact, tt.want, tt.exact)
+
+			}
+		})
+	}
+}
+
+func TestExampleFor_ParserWithIntervalsErrors(t *testing.T) {
+	p := NewParser(WithIntervals())
+
+	for _, tt := range []struct {
+		name string
+		args string
+		want string
+	}{
+		{"test#1", "1/[-1, 2]", "division by an interval containing zero"},
+		{"test#2", "[3, 2]", "lower bound 3 exceeds upper bound 2"},
+		{"test#3", "1 ± -1", "negative tolerance: -1"},
+		{"test#4", "sqrt([-4, -1])", "square root of a negative number"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ParseValue(context.TODO(), tt.args)
+			switch {
+			case err == nil:
+				t.Errorf("Parsing expression %q succeeded, want error", tt.args)
+
+			case err.Error() != tt.want:
+				t.Errorf("Error parsing expression %q: %v, want %s", tt.args, err, tt.want)
+
+			}
+		})
+	}
+}
+
+func TestIntervalText(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		lo, hi float64
+		format byte
+		prec   int
+		want   string
+	}{
+		{"test#1", 1, 2, 'g', 10, "[1, 2]"},
+		{"test#2", 9.8, 10.25, 'g', -1, "[9.8, 10.25]"},
+		{"test#3", 0.1, 0.2, 'f', 3, "[0.100, 0.201]"},
+		{"test#4", 1.5, 1.5, 'g', 10, "[1.5, 1.5]"},
+		{"test#5", -0.5, 0.25, 'e', 2, "[-5.00e-01, 2.50e-01]"},
+		{"test#6", 9.8, 9.8, 'g', -1, "[9.8, 9.800000000000001]"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (Interval{Lo: big.NewFloat(tt.lo), Hi: big.NewFloat(tt.hi)}).Text(tt.format, tt.prec); got != tt.want {
+				t.Errorf("Text of [%g, %g]: %s, want %s", tt.lo, tt.hi, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SchemaVersion is the version of the JSON schema of parse trees written by MarshalJSON.
+// Version 1 nested the nodes, which limited the depth of the trees, and is still read by UnmarshalJSON.
+const SchemaVersion = 2
+
+// document is the JSON document of a parse tree. Its nodes are stored in a flat array, the root comes first
+// and the nodes refer to their operands, arguments or elements by their indices, which are greater than their own.
+// E.g., the tree of sin(x) + 2 is encoded as
+//
+//	{
+//	  "version": 2,
+//	  "nodes": [
+//	    {"type": "operator", "operator": "+", "operands": [1, 2]},
+//	    {"type": "call", "function": "sin", "arguments": [3]},
+//	    {"type": "number", "value": "2"},
+//	    {"type": "identifier", "name": "x"}
+//	  ]
+//	}
+type document struct {
+	Version int           `json:"version"`
+	Nodes   []encodedNode `json:"nodes,omitempty"`
+	Root    *nestedNode   `json:"root,omitempty"` // version 1
+}
+
+// encodedNode is a node in the JSON schema of parse trees.
+// Unlike in the parse tree, the arguments of function calls and the elements of lists are arrays.
+type encodedNode struct {
+	Type      string `json:"type"`                // number, identifier, operator, call or list
+	Value     string `json:"value,omitempty"`     // number
+	Name      string `json:"name,omitempty"`      // identifier
+	Operator  string `json:"operator,omitempty"`  // operator
+	Operands  []int  `json:"operands,omitempty"`  // operator, unary operators have a single operand
+	Function  string `json:"function,omitempty"`  // call
+	Arguments []int  `json:"arguments,omitempty"` // call
+	Elements  []int  `json:"elements,omitempty"`  // list
+}
+
+// nestedNode is a node in version 1 of the JSON schema, which holds its operands, arguments or elements.
+type nestedNode struct {
+	Type      string        `json:"type"`
+	Value     string        `json:"value,omitempty"`
+	Name      string        `json:"name,omitempty"`
+	Operator  string        `json:"operator,omitempty"`
+	Operands  []*nestedNode `json:"operands,omitempty"`
+	Function  string        `json:"function,omitempty"`
+	Arguments []*nestedNode `json:"arguments,omitempty"`
+	Elements  []*nestedNode `json:"elements,omitempty"`
+}
+
+// arity is the number of operands of the operators, the minus is either binary or unary.
+var arity = map[string][]int{
+	"+": {2}, "-": {2, 1}, "±": {2}, "*": {2}, "/": {2}, "^": {2}, "@": {2}, "[]": {2},
+	"!": {1}, "°": {1}, "√": {1},
+}
+
+// MarshalJSON encodes the parse tree of the node in the versioned JSON schema, see SchemaVersion.
+func (node *node) MarshalJSON() ([]byte, error) {
+	return json.Marshal(document{Version: SchemaVersion, Nodes: encode(node)})
+}
+
+// UnmarshalJSON decodes the parse tree from the versioned JSON schema into the node.
+// The decoded tree evaluates the same as the encoded one.
+func (node *node) UnmarshalJSON(data []byte) error {
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	switch doc.Version {
+	case 1:
+		doc.Nodes = flatten(doc.Root)
+
+	case SchemaVersion:
+
+	default:
+		return fmt.Errorf("unsupported schema version: %d", doc.Version)
+
+	}
+
+	root, err := decode(doc.Nodes)
+	if err != nil {
+		return err
+	}
+
+	*node = *root
+	return nil
+}
+
+// decode converts the encoded nodes into the parse tree, whose root is the first node.
+// The nodes are decoded in reverse order, so that the operands, arguments or elements of each node are decoded before it.
+func decode(nodes []encodedNode) (*node, error) {
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("missing node")
+	}
+
+	decoded := make([]*node, len(nodes))
+	referenced := make([]bool, len(nodes))
+	for i := len(nodes) - 1; i >= 0; i-- {
+		var children []*node
+		for _, j := range nodes[i].children() {
+			if j <= i || j >= len(nodes) || referenced[j] {
+				return nil, fmt.Errorf("invalid reference of node %d to node %d", i, j)
+			}
+
+			referenced[j] = true
+			children = append(children, decoded[j])
+		}
+
+		var err error
+		if decoded[i], err = decodeNode(&nodes[i], children); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := 1; i < len(nodes); i++ {
+		if !referenced[i] {
+			return nil, fmt.Errorf("node %d is not referenced", i)
+		}
+	}
+
+	return decoded[0], nil
+}
+
+// decodeNode converts the encoded node into a node of the parse tree given its decoded children.
+func decodeNode(encoded *encodedNode, children []*node) (*node, error) {
+	switch encoded.Type {
+	case "":
+		return nil, fmt.Errorf("missing node")
+
+	case "number":
+		n := &node{value: encoded.Value}
+		if _, ok := n.Float(); !ok {
+			return nil, fmt.Errorf("invalid number: %q", encoded.Value)
+		}
+
+		return n, nil
+
+	case "identifier":
+		if encoded.Name == "" {
+			return nil, fmt.Errorf("missing name of identifier")
+		}
+
+		return &node{value: encoded.Name}, nil
+
+	case "operator":
+		counts, ok := arity[encoded.Operator]
+		if !ok {
+			return nil, fmt.Errorf("unsupported operator: %q", encoded.Operator)
+		}
+
+		for _, count := range counts {
+			if len(children) == count {
+				n := &node{value: encoded.Operator, left: children[0]}
+				if count == 2 {
+					n.right = children[1]
+				}
+
+				return n, nil
+			}
+		}
+
+		return nil, fmt.Errorf("operator %s requires %d operands, got %d", encoded.Operator, counts[0], len(children))
+
+	case "call":
+		if encoded.Function == "" {
+			return nil, fmt.Errorf("missing name of function")
+		}
+
+		return &node{value: encoded.Function, left: link(children)}, nil
+
+	case "list":
+		return &node{value: "[", left: link(children)}, nil
+
+	}
+
+	return nil, fmt.Errorf("unsupported node type: %q", encoded.Type)
+}
+
+// children returns the indices of the operands, arguments or elements of the encoded node.
+func (encoded *encodedNode) children() []int {
+	switch encoded.Type {
+	case "operator":
+		return encoded.Operands
+
+	case "call":
+		return encoded.Arguments
+
+	case "list":
+		return encoded.Elements
+
+	}
+
+	return nil
+}
+
+// encode converts the parse tree given by root into encoded nodes in breadth-first order.
+func encode(root *node) []encodedNode {
+	var encoded []encodedNode
+	queue := []*node{root}
+	enqueue := func(nodes ...*node) []int {
+		var indices []int
+		for _, n := range nodes {
+			indices = append(indices, len(queue))
+			queue = append(queue, n)
+		}
+
+		return indices
+	}
+
+	for i := 0; i < len(queue); i++ {
+		n := queue[i]
+		switch _, isOperator := arity[n.value]; {
+		case n.value == "[": // list literals are leaves if empty
+			encoded = append(encoded, encodedNode{Type: "list", Elements: enqueue(items(n.left)...)})
+
+		case n.IsLeaf():
+			if _, ok := n.Float(); ok {
+				encoded = append(encoded, encodedNode{Type: "number", Value: n.value})
+			} else {
+				encoded = append(encoded, encodedNode{Type: "identifier", Name: n.value})
+			}
+
+		case isOperator && n.right != nil:
+			encoded = append(encoded, encodedNode{Type: "operator", Operator: n.value, Operands: enqueue(n.left, n.right)})
+
+		case isOperator:
+			encoded = append(encoded, encodedNode{Type: "operator", Operator: n.value, Operands: enqueue(n.left)})
+
+		default:
+			encoded = append(encoded, encodedNode{Type: "call", Function: n.value, Arguments: enqueue(items(n.left)...)})
+
+		}
+	}
+
+	return encoded
+}
+
+// flatten converts the nested nodes of version 1 of the JSON schema into encoded nodes in breadth-first order.
+// Missing nodes become nodes without type, which fail to decode.
+func flatten(root *nestedNode) []encodedNode {
+	if root == nil {
+		return nil
+	}
+
+	var flattened []encodedNode
+	queue := []*nestedNode{root}
+	enqueue := func(nodes []*nestedNode) []int {
+		var indices []int
+		for _, n := range nodes {
+			if n == nil {
+				n = &nestedNode{}
+			}
+
+			indices = append(indices, len(queue))
+			queue = append(queue, n)
+		}
+
+		return indices
+	}
+
+	for i := 0; i < len(queue); i++ {
+		n := queue[i]
+		flattened = append(flattened, encodedNode{Type: n.Type, Value: n.Value, Name: n.Name, Operator: n.Operator, Function: n.Function})
+		switch encoded := &flattened[i]; n.Type {
+		case "operator":
+			encoded.Operands = enqueue(n.Operands)
+
+		case "call":
+			encoded.Arguments = enqueue(n.Arguments)
+
+		case "list":
+			encoded.Elements = enqueue(n.Elements)
+
+		}
+	}
+
+	return flattened
+}
+
+// items returns the items of the nodes linked as a list, e.g., the arguments of a function call.
+func items(n *node) []*node {
+	var nodes []*node
+	for current := n; current != nil; current = current.right {
+		nodes = append(nodes, current.left)
+	}
+
+	return nodes
+}
+
+// link links the nodes as a list like the arguments of a function call,
+// i.e., each node holds an item in its left child and the next node in its right child.
+func link(items []*node) *node {
+	var list *node
+	for i := len(items) - 1; i >= 0; i-- {
+		list = &node{left: items[i], right: list}
+	}
+
+	return list
+}
@@ -0,0 +1,151 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestNodeJSON(t *testing.T) {
+	p := NewParser(
+		WithFunc("sin", math.Sin),
+		WithFunc("max", math.Max),
+		WithConst("PI", math.Pi),
+		WithVar("x", func() float64 { return 3 }),
+	)
+
+	for _, tt := range []struct {
+		name string
+		args string
+		want string
+	}{
+		{"test#1", "2.5", `{"version":2,"nodes":[{"type":"number","value":"2.5"}]}`},
+		{"test#2", "x", `{"version":2,"nodes":[{"type":"identifier","name":"x"}]}`},
+		{"test#3", "sin(x) + 2", `{"version":2,"nodes":[{"type":"operator","operator":"+","operands":[1,2]},` +
+			`{"type":"call","function":"sin","arguments":[3]},{"type":"number","value":"2"},{"type":"identifier","name":"x"}]}`},
+		{"test#4", "[]", `{"version":2,"nodes":[{"type":"list"}]}`},
+		{"test#5", "√x!", `{"version":2,"nodes":[{"type":"operator","operator":"√","operands":[1]},` +
+			`{"type":"operator","operator":"!","operands":[2]},{"type":"identifier","name":"x"}]}`},
+		{"test#6", "max(x, PI)^2 - -1", ""},
+		{"test#7", "[1, [x, 2]][2][1]*sin(30°)", ""},
+		{"test#8", "len([[1, 2], []]) / 3!", ""},
+		{"test#9", "10 ± 0.5", `{"version":2,"nodes":[{"type":"operator","operator":"±","operands":[1,2]},` +
+			`{"type":"number","value":"10"},{"type":"number","value":"0.5"}]}`},
+		{"test#10", "(x ± 0.1)^2 - 1 ± 2*x", ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := p.Tree(tt.args)
+			if err != nil {
+				t.Fatalf("Error parsing expression %q: %v", tt.args, err)
+			}
+
+			data, err := json.Marshal(root)
+			if err != nil {
+				t.Fatalf("Error encoding %q: %v", tt.args, err)
+			}
+
+			if tt.want != "" && string(data) != tt.want {
+				t.Errorf("JSON of %q: %s, want %s", tt.args, data, tt.want)
+			}
+
+			decoded := NewNode("")
+			if err := json.Unmarshal(data, decoded); err != nil {
+				t.Fatalf("Error decoding %s: %v", data, err)
+			}
+
+			if !equal(decoded.(*node), root.(*node)) {
+				t.Errorf("Decoded tree of %q: %s, want %s", tt.args, decoded, root)
+			}
+
+			want, _ := root.Evaluate(context.TODO(), p)
+			if got, err := decoded.Evaluate(context.TODO(), p); err != nil || got.Text('g', 10) != want.Text('g', 10) {
+				t.Errorf("Result of decoded %q: %v (%v), want %s", tt.args, got, err, want.Text('g', 10))
+			}
+		})
+	}
+}
+
+func TestNodeJSONErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		args string
+		want string
+	}{
+		{"test#1", `{"version":3,"nodes":[{"type":"number","value":"1"}]}`, "unsupported schema version: 3"},
+		{"test#2", `{"version":1}`, "missing node"},
+		{"test#3", `{"version":1,"root":{"type":"number","value":"x"}}`, `invalid number: "x"`},
+		{"test#4", `{"version":1,"root":{"type":"operator","operator":"%"}}`, `unsupported operator: "%"`},
+		{"test#5", `{"version":1,"root":{"type":"operator","operator":"+","operands":[{"type":"number","value":"1"}]}}`,
+			"operator + requires 2 operands, got 1"},
+		{"test#6", `{"version":1,"root":{"type":"call","arguments":[]}}`, "missing name of function"},
+		{"test#7", `{"version":1,"root":{"type":"list","elements":[{"type":"matrix"}]}}`, `unsupported node type: "matrix"`},
+		{"test#8", `[]`, "cannot unmarshal array"},
+		{"test#9", `{"version":2,"nodes":[]}`, "missing node"},
+		{"test#10", `{"version":2,"nodes":[{"type":"operator","operator":"-","operands":[0]}]}`, "invalid reference of node 0 to node 0"},
+		{"test#11", `{"version":2,"nodes":[{"type":"operator","operator":"+","operands":[1,1]},{"type":"number","value":"1"}]}`,
+			"invalid reference of node 0 to node 1"},
+		{"test#12", `{"version":2,"nodes":[{"type":"call","function":"f","arguments":[2]},{"type":"number","value":"1"}]}`,
+			"invalid reference of node 0 to node 2"},
+		{"test#13", `{"version":2,"nodes":[{"type":"list"},{"type":"number","value":"1"}]}`, "node 1 is not referenced"},
+		{"test#14", `{"version":2,"nodes":[{"type":"list","elements":[1]},{}]}`, "missing node"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := json.Unmarshal([]byte(tt.args), NewNode("")); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Error decoding %s: %v, want %q", tt.args, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeJSONVersion1(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		args string
+		want string
+	}{
+		{"test#1", `{"version":1,"root":{"type":"number","value":"2.5"}}`, "2.5"},
+		{"test#2", `{"version":1,"root":{"type":"operator","operator":"+","operands":[` +
+			`{"type":"call","function":"sin","arguments":[{"type":"identifier","name":"x"}]},{"type":"number","value":"2"}]}}`, "sin(x) + 2"},
+		{"test#3", `{"version":1,"root":{"type":"list","elements":[{"type":"list"},` +
+			`{"type":"operator","operator":"!","operands":[{"type":"identifier","name":"x"}]}]}}`, "[[], x!]"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded := NewNode("")
+			if err := json.Unmarshal([]byte(tt.args), decoded); err != nil {
+				t.Fatalf("Error decoding %s: %v", tt.args, err)
+			}
+
+			if !equal(decoded.(*node), tree(t, tt.want)) {
+				t.Errorf("Decoded tree of %s: %s, want %s", tt.args, decoded, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeJSONDeepTree(t *testing.T) {
+	p := NewParser()
+	for _, depth := range []int{6000, 100000} {
+		expr := strings.Repeat("1+(", depth) + "1" + strings.Repeat(")", depth)
+		root, err := p.Tree(expr)
+		if err != nil {
+			t.Fatalf("Error parsing expression of depth %d: %v", depth, err)
+		}
+
+		data, err := json.Marshal(root)
+		if err != nil {
+			t.Fatalf("Error encoding tree of depth %d: %v", depth, err)
+		}
+
+		decoded := NewNode("")
+		if err := json.Unmarshal(data, decoded); err != nil {
+			t.Fatalf("Error decoding tree of depth %d: %v", depth, err)
+		}
+
+		if got, err := decoded.Evaluate(context.TODO(), p); err != nil || got.Text('g', -1) != strconv.Itoa(depth+1) {
+			t.Errorf("Result of decoded tree of depth %d: %v (%v), want %d", depth, got, err, depth+1)
+		}
+	}
+}
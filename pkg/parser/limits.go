@@ -0,0 +1,108 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"sync/atomic"
+)
+
+// names of the limits reported by LimitError
+const (
+	LengthLimit       = "length"
+	TokensLimit       = "tokens"
+	DepthLimit        = "depth"
+	FactorialLimit    = "factorial"
+	ExponentBitsLimit = "exponent bits"
+	StepsLimit        = "steps"
+)
+
+// Limits are resource limits of the parser against runaway expressions, see WithLimits.
+// Limits of zero or less are disabled.
+type Limits struct {
+	Length       int // maximum number of characters of an expression
+	Tokens       int // maximum number of tokens of an expression
+	Depth        int // maximum nesting depth of the parse tree
+	Factorial    int // maximum argument of the factorial and of the functions marked by WithFactorialLimit
+	ExponentBits int // maximum size of the result of an exponentiation in bits
+	Steps        int // maximum number of evaluated nodes of an evaluation
+}
+
+// LimitError is the error returned if an expression breaks a limit.
+type LimitError struct {
+	Limit string // name of the limit, e.g., DepthLimit
+	Max   int    // configured maximum
+}
+
+// Error returns the error message.
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s limit of %d exceeded", e.Limit, e.Max)
+}
+
+// stepsKey is the context key of the counter of evaluated nodes.
+type stepsKey struct{}
+
+// count counts the evaluation of a node in the context and fails if the steps limit is exceeded.
+// The counter is added to the context by the evaluation of the root node.
+func (p *parser) count(ctx context.Context) (context.Context, error) {
+	if p.limits.Steps <= 0 {
+		return ctx, nil
+	}
+
+	counter, ok := ctx.Value(stepsKey{}).(*atomic.Int64)
+	if !ok {
+		counter = new(atomic.Int64)
+		ctx = context.WithValue(ctx, stepsKey{}, counter)
+	}
+
+	return ctx, exceeded(StepsLimit, p.limits.Steps, int(counter.Add(1)))
+}
+
+// factorial fails if any of the scalars of the values exceeds the factorial limit.
+func (p *parser) factorial(values ...Value) error {
+	limit := p.limits.Factorial
+	if limit <= 0 {
+		return nil
+	}
+
+	floats, err := Floats(values...)
+	if err != nil {
+		return nil // other values than numbers and lists are validated by the functions
+	}
+
+	for _, x := range floats {
+		if x.Cmp(big.NewFloat(float64(limit))) > 0 {
+			return &LimitError{Limit: FactorialLimit, Max: limit}
+		}
+	}
+
+	return nil
+}
+
+// exceeded returns a LimitError if the value exceeds the limit.
+func exceeded(limit string, max, value int) error {
+	if max > 0 && value > max {
+		return &LimitError{Limit: limit, Max: max}
+	}
+
+	return nil
+}
+
+// exponentBits estimates the size of x^y in bits, i.e., |y*log2(|x|)|.
+func exponentBits(x, y *big.Float) float64 {
+	if x.Sign() == 0 {
+		return 0
+	}
+
+	mantissa := new(big.Float)
+	exponent := x.MantExp(mantissa)
+	m, _ := mantissa.Float64()
+	log := float64(exponent) + math.Log2(math.Abs(m))
+	if log == 0 {
+		return 0
+	}
+
+	f, _ := y.Float64()
+	return math.Abs(f * log)
+}
@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sarumaj/edu-taschenrechner/pkg/calc"
+)
+
+func TestExampleFor_ParserWithLimits(t *testing.T) {
+	limits := Limits{Length: 64, Tokens: 24, Depth: 8, Factorial: 1000, ExponentBits: 4096, Steps: 12}
+
+	for _, tt := range []struct {
+		name    string
+		args    string
+		want    string
+		wantErr string // name of the broken limit
+	}{
+		{"test#1", "2^10 + 5! - (1 + 2)*3", "1135", ""},
+		{"test#2", "1000! / 1000!", "1", ""},
+		{"test#3", "2^4096 / 2^4095", "2", ""},
+		{"test#4", strings.Repeat("1 + ", 16) + "1", "", LengthLimit},
+		{"test#5", strings.Repeat("1+", 12) + "1", "", TokensLimit},
+		{"test#6", strings.Repeat("(", 8) + "1" + strings.Repeat(")", 8) + "+1", "2", ""},
+		{"test#7", "√√√√√√√√1", "", DepthLimit},
+		{"test#8", "999999!", "", FactorialLimit},
+		{"test#9", "9^9^9", "", ExponentBitsLimit},
+		{"test#10", "0.5^5000", "", ExponentBitsLimit},
+		{"test#11", "[1, 2, 3, 4, 5, 6, 7, 8, 9, 10] + 1", "", StepsLimit},
+		{"test#12", "(-1)^1000000001 + 1^100000000", "0", ""},
+		{"test#13", "fib(90) - fib(89)", "1.100087778e+18", ""},
+		{"test#14", "fib(1001)", "", FactorialLimit},
+		{"test#15", "fib([10, 5000])", "", FactorialLimit},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewParser(
+				WithFunc("fib", calc.Fibonacci),
+				WithFactorialLimit("fib"),
+				WithLimits(limits),
+			).ParseValue(context.TODO(), tt.args)
+
+			var limitErr *LimitError
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Error parsing expression %q: %v", tt.args, err)
+
+			case tt.wantErr == "" && got.Text('g', 10) != tt.want:
+				t.Errorf("Result of %q: %s, want %s", tt.args, got.Text('g', 10), tt.want)
+
+			case tt.wantErr != "" && (!errors.As(err, &limitErr) || limitErr.Limit != tt.wantErr):
+				t.Errorf("Error parsing expression %q: %v, want %s limit to be exceeded", tt.args, err, tt.wantErr)
+
+			}
+		})
+	}
+}
+
+func TestLimitsDisabled(t *testing.T) {
+	got, err := NewParser(WithLimits(Limits{})).Parse(context.TODO(), "2^5000 / 2^4999 + 200!/199!")
+	if err != nil || got.Text('g', 10) != "202" {
+		t.Errorf("Result with disabled limits: %v (%v), want 202", got, err)
+	}
+}
+
+func TestDepthLimitWhileParsing(t *testing.T) {
+	p := NewParser(WithLimits(Limits{Depth: 100}))
+	for _, tt := range []struct {
+		name string
+		args string
+	}{
+		{"test#1", strings.Repeat("-(", 1<<20) + "1" + strings.Repeat(")", 1<<20)},
+		{"test#2", strings.Repeat("[", 1<<20)},
+		{"test#3", strings.Repeat("1+", 1<<20) + "1"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var limitErr *LimitError
+			if _, err := p.Tree(tt.args); !errors.As(err, &limitErr) || limitErr.Limit != DepthLimit {
+				t.Errorf("Error parsing deeply nested expression: %v, want depth limit to be exceeded", err)
+			}
+		})
+	}
+}
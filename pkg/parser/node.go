@@ -18,14 +18,18 @@ type Node = NodeInterface[*node]
 // NodeInterface is a generic interface for nodes in the parse tree
 // It is used to define the methods that are common to all nodes
 type NodeInterface[n any] interface {
-	Evaluate(ctx context.Context, p *parser) (*big.Float, error)
+	Evaluate(ctx context.Context, p *parser) (Value, error)
+	EvaluateExact(ctx context.Context, p *parser) (Value, bool, error)
 	Float() (*big.Float, bool)
 	IsLeaf() bool
 	Left() n
+	MarshalJSON() ([]byte, error)
 	Right() n
 	SetLeft(left any) n
 	SetRight(right any) n
 	SetValue(value string) n
+	String() string
+	UnmarshalJSON(data []byte) error
 	Value() string
 }
 
@@ -36,114 +40,416 @@ type node struct {
 	right *node
 }
 
-// Evaluate evaluates the node and returns the result
-func (node *node) Evaluate(ctx context.Context, p *parser) (*big.Float, error) {
-	// Check if context is done
-	if err := ctx.Err(); err != nil {
-		return nil, err
-	}
+// Evaluate evaluates the node and returns the result, the time and memory taken grow linearly with the size of the tree.
+// Undefined results, which math/big signals by panicking with big.ErrNaN, are returned as errors wrapping ErrNaN.
+func (node *node) Evaluate(ctx context.Context, p *parser) (Value, error) {
+	result, _, err := node.EvaluateExact(ctx, p)
+	return result, err
+}
+
+// EvaluateExact evaluates the node like Evaluate and additionally reports whether the result is exact,
+// i.e., whether no rounding occurred during the evaluation, see WithApproximate.
+func (node *node) EvaluateExact(ctx context.Context, p *parser) (result Value, exact bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			nan, ok := r.(big.ErrNaN)
+			if !ok {
+				panic(r)
+			}
 
-	if node.IsLeaf() { // Leaf node, check if it is a variable or a number
-		if val, ok := p.LookupConst(node.value); ok {
-			return val, nil
+			result, exact, err = nil, false, fmt.Errorf("%w: %s", ErrNaN, nan.Error())
 		}
+	}()
 
-		if val, ok := p.LookupVariable(node.value); ok {
-			return val(), nil
+	return node.evaluate(ctx, p)
+}
+
+// frame is a node on the stack of an evaluation along with the values of its operands evaluated so far.
+// The operands are the arguments of function calls, the elements of list literals or the operands of operators.
+type frame struct {
+	node        *node
+	fn          func(context.Context, ...Value) (Value, error) // function of a function call
+	approximate bool                                           // whether the function is approximate, see WithApproximate
+	interval    func(Interval) (Interval, error)               // interval version of a standard function, see WithIntervals
+	operands    []*node
+	values      List
+	exact       bool           // whether the values of the operands evaluated so far are exact
+	futures     []chan outcome // outcomes of the operands evaluated by other goroutines, see fork
+}
+
+// newFrame returns the frame of the node with room for the values of its operands.
+func newFrame(n *node, fn func(context.Context, ...Value) (Value, error), operands []*node) frame {
+	return frame{node: n, fn: fn, operands: operands, values: make(List, 0, len(operands)), exact: true}
+}
+
+// evaluate evaluates the node and returns the result along with whether it is exact.
+// The tree is traversed iteratively using an explicit stack, hence arbitrarily deep trees do not exhaust the call stack.
+// The operands of each node are evaluated from left to right before the node itself.
+func (root *node) evaluate(ctx context.Context, p *parser) (Value, bool, error) {
+	ctx, cancel := p.parallel(ctx)
+	defer cancel()
+
+	var stack []frame
+	var result Value
+	var exact bool
+
+	// deliver passes the value of a node to the node waiting for it on the stack, if there is any
+	deliver := func(value Value, isExact bool) {
+		if len(stack) == 0 {
+			result, exact = value, isExact
+		} else {
+			top := &stack[len(stack)-1]
+			top.values = append(top.values, value)
+			top.exact = top.exact && isExact
 		}
+	}
 
-		if val, ok := node.Float(); ok {
-			return val, nil
+	// push evaluates leaves and special forms at once, other nodes are pushed on the stack
+	push := func(n *node) error {
+		// Check if context is done
+		if err := ctx.Err(); err != nil {
+			return err
 		}
 
-		return nil, fmt.Errorf("undefined variable or function: %s", node.value)
-	}
+		// Count the evaluation step
+		var err error
+		if ctx, err = p.count(ctx); err != nil {
+			return err
+		}
+
+		var f frame
+		switch fn, isFunc := p.function(n.value); {
+		case n.value == "[": // List literal, elements are linked as a list like function arguments
+			f = newFrame(n, nil, n.Left().links())
 
-	// Handle function calls
-	if fn, ok := p.LookupFunc(node.value); ok {
-		// Collect all arguments
-		var args []*big.Float
-		// Extract the arguments from the nodes in the left subtree, from left to right
-		for currentNode := node.Left(); currentNode != nil; currentNode = currentNode.Right() {
-			arg, err := currentNode.Left().Evaluate(ctx, p)
+		case n.IsLeaf(): // Leaf node, check if it is a variable or a number
+			value, isExact, err := n.evaluateLeaf(p)
 			if err != nil {
-				return nil, err
+				return err
 			}
-			args = append(args, arg)
+
+			deliver(value, isExact)
+			return nil
+
+		case n.value == "diff": // Differentiation, the arguments are differentiated instead of being evaluated
+			value, isExact, err := n.Left().differentiate(ctx, p)
+			if err != nil {
+				return err
+			}
+
+			p.observe(n, nil, value)
+			deliver(value, isExact)
+			return nil
+
+		case p.intervals && standard[n.value] != nil: // Standard function evaluated by its interval version
+			f = newFrame(n, nil, n.Left().links())
+			f.interval = standard[n.value]
+
+		case isFunc: // Function call, the arguments are linked in the left subtree from left to right
+			f = newFrame(n, fn.call(n.value, p), n.Left().links())
+			f.approximate, f.interval = fn.approximate, standard[n.value]
+
+		case n.Left() == nil:
+			return fmt.Errorf("missing left operand for operator %s", n.value)
+
+		case n.Right() == nil: // Unary operator
+			f = newFrame(n, nil, []*node{n.Left()})
+
+		default: // Binary operator
+			f = newFrame(n, nil, []*node{n.Left(), n.Right()})
+
 		}
 
-		// Call the function with the evaluated arguments
-		return fn(args...)
+		f.fork(ctx, p)
+		stack = append(stack, f)
+		return nil
 	}
 
-	if node.Left() == nil {
-		return nil, fmt.Errorf("missing left operand for operator %s", node.value)
+	if err := push(root); err != nil {
+		return nil, false, err
 	}
 
-	// Evaluate the left subtree
-	left, err := node.Left().Evaluate(ctx, p)
-	if err != nil {
-		return nil, err
-	}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if i := len(top.values); i < len(top.operands) { // Evaluate the next operand
+			if future := top.future(i); future != nil { // Wait for the operand evaluated by another goroutine
+				o := <-future
+				if o.panic != nil {
+					panic(o.panic)
+				}
+				if o.err != nil {
+					return nil, false, o.err
+				}
+
+				deliver(o.value, o.exact)
+				continue
+			}
 
-	// Handle unary operators
-	switch node.Value() {
-	case "!": // Factorial
-		left, err = calc.Factorial(ctx, left, 1)
+			if err := push(top.operands[i]); err != nil {
+				return nil, false, err
+			}
+			continue
+		}
+
+		value, isExact, err := top.reduce(ctx, p)
+		stack = stack[:len(stack)-1]
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
-	case "°": // Convert the result from degrees to radians
-		left = big.NewFloat(0).Mul(left, big.NewFloat(0).Quo(big.NewFloat(math.Pi), big.NewFloat(180)))
+		deliver(value, isExact)
+	}
 
-	case "√": // Square root
-		if left.Cmp(big.NewFloat(0)) < 0 {
-			return nil, fmt.Errorf("square root of a negative number")
+	return result, exact, nil
+}
+
+// evaluateLeaf returns the value of the constant, variable or number of the leaf node along with whether it is exact.
+func (node *node) evaluateLeaf(p *parser) (Value, bool, error) {
+	if val, ok := p.LookupConst(node.value); ok {
+		if p.intervals && p.approximated(node.value) { // Enclose the exact value, e.g., of π
+			enclosure := widen(val)
+			p.observe(node, nil, enclosure)
+			return enclosure, false, nil
 		}
-		left = big.NewFloat(0).Sqrt(left)
 
-	case "-": // Unary minus
-		if node.Right() == nil {
-			return big.NewFloat(0).Neg(left), nil
+		p.observe(node, nil, val)
+		return val, accurate(val) && !p.approximated(node.value), nil
+	}
+
+	if val, ok := p.LookupVariable(node.value); ok {
+		if v := val(); v != nil {
+			if p.intervals && p.approximated(node.value) {
+				v = widen(v)
+			}
+
+			p.observe(node, nil, v)
+			return v, accurate(v) && !p.approximated(node.value), nil
 		}
 
+		return nil, false, fmt.Errorf("variable %s has no value", node.value)
 	}
 
-	// If there is no right node, return the result
-	if node.Right() == nil {
-		return left, nil
+	if val, ok := node.Float(); ok {
+		if p.intervals { // Enclose numbers not representable exactly, e.g., 9.8
+			enclosure, _ := literal(node.value)
+			return enclosure, accurate(enclosure), nil
+		}
+
+		return val, accurate(val), nil
+	}
+
+	return nil, false, fmt.Errorf("undefined variable or function: %s", node.value)
+}
+
+// reduce applies the function, list literal or operator of the frame to the values of its operands.
+// The result is exact if the values of the operands are exact and no rounding occurred applying it.
+func (f *frame) reduce(ctx context.Context, p *parser) (Value, bool, error) {
+	var result Value
+	var exact bool
+	var err error
+	switch node := f.node; {
+	case node.value == "[" && p.intervals && f.bounds(): // Interval literal, e.g., [9.8, 9.81]
+		result, err = hull(f.values[0], f.values[1])
+		exact = accurate(result)
+
+	case node.value == "[": // List literal
+		return append(List{}, f.values...), f.exact, nil
+
+	case f.interval != nil && p.enclosed(f.values...): // Interval version of a standard function
+		if len(f.values) != 1 {
+			return nil, false, fmt.Errorf("%s function requires exactly %s", node.value, plural(1, "argument"))
+		}
+
+		result, err = each(f.values[0], func(element Value) (Value, error) {
+			x, err := enclose(element)
+			if err != nil {
+				return nil, err
+			}
+
+			y, err := f.interval(x)
+			if err != nil {
+				return nil, err
+			}
+
+			return y.value(), nil
+		})
+		exact = accurate(result)
+
+	case f.fn != nil: // Call the function with the evaluated arguments
+		result, err = f.fn(ctx, f.values...)
+		exact = err == nil && !f.approximate && accurate(result)
+
+	case len(f.values) == 1: // Handle unary operators
+		result, exact, err = node.evaluateUnary(ctx, p, f.values[0])
+
+	default: // Handle binary operators
+		result, exact, err = node.evaluateBinary(ctx, p, f.values[0], f.values[1])
+
 	}
 
-	// Evaluate the right subtree
-	right, err := node.Right().Evaluate(ctx, p)
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+
+	p.observe(f.node, f.values, result)
+	return result, f.exact && exact, nil
+}
+
+// bounds reports whether the list literal of the frame denotes an interval, i.e., whether it consists of two elements,
+// which are neither lists nor list literals, e.g., [9.8, 9.81], but not [[1, 2], [3, 4]], see WithIntervals.
+func (f *frame) bounds() bool {
+	if len(f.values) != 2 {
+		return false
+	}
+
+	for i, value := range f.values {
+		if _, isList := value.(List); isList || f.operands[i].value == "[" {
+			return false
+		}
 	}
 
-	// Handle binary operators
+	return true
+}
+
+// evaluateUnary applies the unary operator of the node to the value of its operand
+// and reports whether no rounding occurred applying it.
+func (node *node) evaluateUnary(ctx context.Context, p *parser, left Value) (Value, bool, error) {
+	switch node.Value() {
+	case "!": // Factorial
+		result, err := apply(left, func(x *big.Float) (Value, error) {
+			if err := p.factorial(x); err != nil {
+				return nil, err
+			}
+			return calc.Factorial(ctx, x, 1)
+		})
+		return result, accurate(result), err
+
+	case "°": // Convert the result from degrees to radians
+		exact := true
+		result, err := p.elementwise(left, func(x *big.Float) (Value, error) {
+			exact = exact && x.Sign() == 0 // π is approximated
+			return big.NewFloat(0).Mul(x, big.NewFloat(0).Quo(big.NewFloat(math.Pi), big.NewFloat(180))), nil
+		}, func(x Interval) (Interval, error) {
+			y, err := x.mul(radian)
+			exact = exact && err == nil && y.Lo.Cmp(y.Hi) == 0
+			return y, err
+		})
+		return result, exact, err
+
+	case "√": // Square root
+		exact := true
+		result, err := p.elementwise(left, func(x *big.Float) (Value, error) {
+			if x.Cmp(big.NewFloat(0)) < 0 {
+				return nil, fmt.Errorf("square root of a negative number")
+			}
+			root := big.NewFloat(0).Sqrt(x)
+			exact = exact && exactRoot(x, root)
+			return root, nil
+		}, func(x Interval) (Interval, error) {
+			root, err := x.sqrt()
+			exact = exact && err == nil && root.Lo.Cmp(root.Hi) == 0
+			return root, err
+		})
+		return result, exact, err
+
+	case "-": // Unary minus
+		result, err := p.elementwise(left, func(x *big.Float) (Value, error) { return big.NewFloat(0).Neg(x), nil },
+			func(x Interval) (Interval, error) { return x.neg(), nil })
+		return result, true, err
+
+	}
+
+	// Any other node without right operand evaluates to its operand
+	return left, true, nil
+}
+
+// evaluateBinary applies the binary operator of the node to the values of its operands
+// and reports whether no rounding occurred applying it.
+func (node *node) evaluateBinary(ctx context.Context, p *parser, left, right Value) (Value, bool, error) {
+	var result Value
+	var err error
 	switch node.Value() {
 	case "+": // Addition
-		return big.NewFloat(0).Add(left, right), nil
+		result, err = p.arithmetic(left, right, func(x, y *big.Float) (Value, error) { return big.NewFloat(0).Add(x, y), nil }, Interval.add)
 
 	case "-": // Subtraction
-		return big.NewFloat(0).Sub(left, right), nil
+		result, err = p.arithmetic(left, right, func(x, y *big.Float) (Value, error) { return big.NewFloat(0).Sub(x, y), nil }, Interval.sub)
 
 	case "*": // Multiplication
-		return big.NewFloat(0).Mul(left, right), nil
+		result, err = p.arithmetic(left, right, func(x, y *big.Float) (Value, error) { return big.NewFloat(0).Mul(x, y), nil }, Interval.mul)
 
 	case "/": // Division
-		if right.Cmp(big.NewFloat(0)) == 0 {
-			return nil, fmt.Errorf("division by zero")
-		}
-		return big.NewFloat(0).Quo(left, right), nil
+		result, err = p.arithmetic(left, right, func(x, y *big.Float) (Value, error) {
+			if y.Cmp(big.NewFloat(0)) == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			return big.NewFloat(0).Quo(x, y), nil
+		}, Interval.quo)
+
+	case "±": // Tolerance, the interval of the left operand widened by the right one on both sides
+		result, err = combine(left, right, func(x, y Value) (Value, error) {
+			a, err := enclose(x)
+			if err != nil {
+				return nil, err
+			}
+
+			b, err := enclose(y)
+			if err != nil {
+				return nil, err
+			}
+
+			interval, err := a.tolerance(b)
+			if err != nil {
+				return nil, err
+			}
+
+			return interval.value(), nil
+		})
 
 	case "^": // Exponentiation
-		return calc.Pow(ctx, left, right)
+		exact := true
+		result, err = p.arithmetic(left, right, func(x, y *big.Float) (Value, error) {
+			if limit := p.limits.ExponentBits; limit > 0 && exponentBits(x, y) > float64(limit) {
+				return nil, &LimitError{Limit: ExponentBitsLimit, Max: limit}
+			}
+			power, err := calc.Pow(ctx, x, y)
+			exact = exact && err == nil && exactPower(x, y, power)
+			return power, err
+		}, func(x, y Interval) (Interval, error) {
+			if limit := p.limits.ExponentBits; limit > 0 && max(exponentBits(x.Lo, y.Hi), exponentBits(x.Hi, y.Hi), exponentBits(x.Lo, y.Lo), exponentBits(x.Hi, y.Lo)) > float64(limit) {
+				return Interval{}, &LimitError{Limit: ExponentBitsLimit, Max: limit}
+			}
+			power, err := x.pow(y)
+			exact = exact && err == nil && power.Lo.Cmp(power.Hi) == 0
+			return power, err
+		})
+		return result, exact, err
+
+	case "@": // Matrix multiplication
+		result, err = product(ctx, left, right)
+
+	case "[]": // Indexing (1-based)
+		result, err = index(left, right)
+		return result, true, err
 
 	default:
-		return nil, fmt.Errorf("unsupported operator: %s", node.value)
+		return nil, false, fmt.Errorf("unsupported operator: %s", node.value)
 	}
+
+	// The results of the arithmetic operators are rounded at most once, hence their accuracy tells whether they are exact.
+	// The elements of matrix products are sums of products, whose accuracy only tells about the last addition.
+	return result, err == nil && accurate(result), err
+}
+
+// links returns the nodes linked as a list in the left children of node and its right descendants,
+// e.g., the arguments of a function call or the elements of a list literal.
+func (n *node) links() []*node {
+	var items []*node
+	for current := n; current != nil; current = current.Right() {
+		items = append(items, current.Left())
+	}
+
+	return items
 }
 
 // Float converts the node value to a big.Float
@@ -180,6 +486,9 @@ func (node *node) SetValue(value string) *node {
 	return node
 }
 
+// String returns the expression of the subtree in infix notation
+func (node *node) String() string { return Format(node, Infix) }
+
 // Value returns the node value
 func (node *node) Value() string { return node.value }
 
@@ -187,3 +496,74 @@ func (node *node) Value() string { return node.value }
 func NewNode(value string) Node {
 	return &node{value: value}
 }
+
+// index returns the element of the list at the given 1-based position.
+func index(list, position Value) (Value, error) {
+	elements, ok := list.(List)
+	if !ok {
+		return nil, fmt.Errorf("only lists can be indexed")
+	}
+
+	scalar, ok := Scalar(position)
+	if !ok {
+		return nil, fmt.Errorf("index must be a number")
+	}
+
+	i, accuracy := scalar.Int64()
+	if accuracy != big.Exact || i < 1 || i > int64(len(elements)) {
+		return nil, fmt.Errorf("index %s out of range [1, %d]", scalar.Text('g', 10), len(elements))
+	}
+
+	return elements[i-1], nil
+}
+
+// product calculates the matrix product of left and right.
+// A list of numbers on the left is treated as a row vector, on the right as a column vector,
+// and the corresponding dimension is removed from the result.
+func product(ctx context.Context, left, right Value) (Value, error) {
+	a, err := Matrix(left)
+	if err != nil {
+		return nil, fmt.Errorf("matrix multiplication: %w", err)
+	}
+
+	b, err := Matrix(right)
+	if err != nil {
+		return nil, fmt.Errorf("matrix multiplication: %w", err)
+	}
+
+	leftIsVector, rightIsVector := len(a) == 1 && !isMatrix(left), len(b) == 1 && !isMatrix(right)
+	if rightIsVector { // column vector
+		b, _ = calc.Transpose(ctx, b)
+	}
+
+	rows, err := calc.MatrixMultiply(ctx, a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case leftIsVector && rightIsVector:
+		return rows[0][0], nil
+
+	case leftIsVector:
+		return scalars(rows[0]), nil
+
+	case rightIsVector:
+		column, _ := calc.Transpose(ctx, rows)
+		return scalars(column[0]), nil
+
+	}
+
+	return matrix(rows), nil
+}
+
+// isMatrix reports whether the value is a list of lists.
+func isMatrix(value Value) bool {
+	list, ok := value.(List)
+	if !ok || len(list) == 0 {
+		return false
+	}
+
+	_, ok = list[0].(List)
+	return ok
+}
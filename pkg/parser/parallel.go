@@ -0,0 +1,125 @@
+package parser
+
+import "context"
+
+// largeTree is the number of nodes from which on a subtree is expensive regardless of its operations.
+const largeTree = 1024
+
+// workersKey is the context key of the semaphore limiting the goroutines of a parallel evaluation.
+type workersKey struct{}
+
+// outcome is the outcome of the evaluation of an operand by another goroutine.
+type outcome struct {
+	value Value
+	exact bool
+	err   error
+	panic any // value of a panic other than big.ErrNaN to be raised again by the waiting goroutine
+}
+
+// parallel returns the context of an evaluation along with a function cancelling the goroutines started by it.
+// If parallel evaluation is enabled, see WithParallelism, the semaphore limiting the goroutines
+// is added to the context by the evaluation of the root node and shared by the nested ones.
+func (p *parser) parallel(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.workers <= 0 || p.observer != nil {
+		return ctx, func() {}
+	}
+
+	if _, ok := ctx.Value(workersKey{}).(chan struct{}); !ok {
+		ctx = context.WithValue(ctx, workersKey{}, make(chan struct{}, p.workers))
+	}
+
+	return context.WithCancel(ctx)
+}
+
+// fork starts evaluating the expensive operands of the frame by other goroutines, see WithParallelism.
+// The first expensive operand is left to the current goroutine, as are the operands exceeding the number of idle workers.
+func (f *frame) fork(ctx context.Context, p *parser) {
+	if p.workers <= 0 || p.observer != nil || len(f.operands) < 2 {
+		return
+	}
+
+	workers, ok := ctx.Value(workersKey{}).(chan struct{})
+	if !ok {
+		return
+	}
+
+	var expensive []int
+	for i, operand := range f.operands {
+		if operand.expensive(p) {
+			expensive = append(expensive, i)
+		}
+	}
+
+	if len(expensive) < 2 {
+		return
+	}
+
+	f.futures = make([]chan outcome, len(f.operands))
+	for _, i := range expensive[1:] {
+		select {
+		case workers <- struct{}{}:
+		default: // All workers are busy
+			return
+		}
+
+		future := make(chan outcome, 1)
+		f.futures[i] = future
+		go func(operand *node) {
+			defer func() { <-workers }()
+			future <- operand.outcome(ctx, p)
+		}(f.operands[i])
+	}
+}
+
+// future returns the channel delivering the outcome of the i-th operand, if it is evaluated by another goroutine.
+func (f *frame) future(i int) chan outcome {
+	if f.futures == nil {
+		return nil
+	}
+
+	return f.futures[i]
+}
+
+// outcome evaluates the node capturing panics, which are raised again by the goroutine waiting for the outcome.
+func (n *node) outcome(ctx context.Context, p *parser) (o outcome) {
+	defer func() {
+		if r := recover(); r != nil {
+			o = outcome{panic: r}
+		}
+	}()
+
+	o.value, o.exact, o.err = n.EvaluateExact(ctx, p)
+	return o
+}
+
+// expensive estimates whether the evaluation of the subtree is worth another goroutine.
+// Subtrees containing factorials, exponentiations, matrix products or function calls are expensive, as are large ones.
+// The estimation visits at most largeTree nodes.
+func (n *node) expensive(p *parser) bool {
+	pending := []*node{n}
+	for visited := 0; len(pending) > 0; visited++ {
+		if visited == largeTree {
+			return true
+		}
+
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if current == nil || current.IsLeaf() {
+			continue
+		}
+
+		switch _, isFunc := p.function(current.value); {
+		case current.value == "!", current.value == "^", current.value == "@", current.value == "diff", isFunc:
+			return true
+
+		}
+
+		for _, child := range []*node{current.left, current.right} {
+			if child != nil {
+				pending = append(pending, child)
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,121 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestExampleFor_ParserWithParallelism(t *testing.T) {
+	sequential := NewParser(WithFunc("fact", func(x float64) float64 {
+		r := 1.0
+		for i := 2.0; i <= x; i++ {
+			r *= i
+		}
+		return r
+	}))
+	parallel := sequential.With(WithParallelism(4))
+
+	for _, tt := range []struct {
+		name string
+		args string
+		want string
+	}{
+		{"test#1", "100! / 98! + fact(10)", "3638700"},
+		{"test#2", "2^10 - 3^4 * 2", "862"},
+		{"test#3", "fact(3) + fact(4) + fact(5) + 5!", "270"},
+		{"test#4", "[10!/9!, fact(4), 2^3, 1 + 1]", "[10, 24, 8, 2]"},
+		{"test#5", "fact(5) * 2 - (4! + 3!)^2", "-660"},
+		{"test#6", "((2^2)! - (3!)!) / (1 + 1)", "-348"},
+		{"test#7", "diff(x^2, x, 3) * diff(x^3, x, 2)", "72"},
+		{"test#8", "1 + 2 * 3", "7"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := sequential.ParseValue(context.TODO(), tt.args)
+			if err != nil {
+				t.Fatalf("Error evaluating %q sequentially: %v", tt.args, err)
+			}
+
+			got, err := parallel.ParseValue(context.TODO(), tt.args)
+			if err != nil {
+				t.Errorf("Error evaluating %q in parallel: %v", tt.args, err)
+				return
+			}
+
+			if got.Text('g', 50) != want.Text('g', 50) || got.Text('g', 50) != tt.want {
+				t.Errorf("Result of %q in parallel: %s, sequentially: %s, want %s", tt.args, got.Text('g', 50), want.Text('g', 50), tt.want)
+			}
+		})
+	}
+}
+
+func TestParallelEvaluationIsConcurrent(t *testing.T) {
+	left, right := make(chan struct{}), make(chan struct{})
+	meet := func(arrived, awaited chan struct{}) func(context.Context, *big.Float) (*big.Float, error) {
+		return func(ctx context.Context, x *big.Float) (*big.Float, error) {
+			close(arrived)
+			select {
+			case <-awaited:
+				return x, nil
+
+			case <-time.After(time.Second):
+				return nil, fmt.Errorf("operands are not evaluated concurrently")
+
+			}
+		}
+	}
+
+	p := NewParser(WithFunc("left", meet(left, right)), WithFunc("right", meet(right, left)), WithParallelism(1))
+	got, err := p.Parse(context.TODO(), "left(1) + right(2)")
+	if err != nil || got.Text('g', 10) != "3" {
+		t.Errorf("Result of concurrently evaluated operands: %v (%v), want 3", got, err)
+	}
+}
+
+func TestParallelEvaluationErrors(t *testing.T) {
+	p := NewParser(
+		WithFunc("sum", func(x ...*big.Float) (*big.Float, error) {
+			sum := big.NewFloat(0)
+			for _, x := range x {
+				sum.Add(sum, x)
+			}
+			return sum, nil
+		}),
+		WithFunc("fail", func(ctx context.Context, x *big.Float) (*big.Float, error) {
+			ms, _ := x.Int64()
+			time.Sleep(time.Duration(ms) * time.Millisecond)
+			return nil, fmt.Errorf("failed after %d ms", ms)
+		}),
+		WithFunc("block", func(ctx context.Context, x *big.Float) (*big.Float, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}),
+		WithParallelism(4),
+	)
+
+	for _, tt := range []struct {
+		name    string
+		args    string
+		timeout time.Duration
+		want    func(error) bool
+	}{
+		{"test#1", "fail(20) + fail(1)", time.Second, func(err error) bool { return err != nil && err.Error() == "failed after 20 ms" }},
+		{"test#2", "fail(1) * block(1)", time.Second, func(err error) bool { return err != nil && err.Error() == "failed after 1 ms" }},
+		{"test#3", "block(1) - 2^10", 10 * time.Millisecond, func(err error) bool { return errors.Is(err, context.DeadlineExceeded) }},
+		{"test#4", "sum(1, 1/0, block(1), 5!)", 10 * time.Millisecond, func(err error) bool { return err != nil && err.Error() == "division by zero" }},
+		{"test#5", "sum(Inf - Inf, 5!)", time.Second, func(err error) bool { return errors.Is(err, ErrNaN) }},
+		{"test#6", "5! + sum(Inf - Inf, 1)", time.Second, func(err error) bool { return errors.Is(err, ErrNaN) }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+
+			if _, err := p.Parse(ctx, tt.args); !tt.want(err) {
+				t.Errorf("Error evaluating %q in parallel: %v", tt.args, err)
+			}
+		})
+	}
+}
@@ -17,15 +17,18 @@ Example:
 	}
 
 	fmt.Println(result) // prints 45
+
+Besides evaluating expressions, parse trees can be simplified, differentiated, formatted and encoded as JSON,
+see Parser.Tree, and the parser options configure, e.g., the input dialect, interval arithmetic and resource limits.
 */
 package parser
 
 import (
 	"context"
 	"fmt"
+	"maps"
+	"math"
 	"math/big"
-	"regexp"
-	"strings"
 )
 
 var _ Parser = (*parser)(nil)
@@ -48,41 +51,58 @@ type Parser = ParserInterface[*parser]
 // ParserInterface is a generic interface for the parser
 type ParserInterface[T any] interface {
 	ApplyOptions(opts ...Option) T
+	Clone() T
+	ListConstants() []Constant
+	ListFunctions() []Function
 	LookupConst(name string) (*big.Float, bool)
-	LookupFunc(name string) (func(...*big.Float) (*big.Float, error), bool)
-	LookupVariable(name string) (func() *big.Float, bool)
+	LookupFunc(name string) (func(context.Context, ...Value) (Value, error), bool)
+	LookupVariable(name string) (func() Value, bool)
 	Parse(ctx context.Context, expr string) (*big.Float, error)
+	ParseExact(ctx context.Context, expr string) (Value, bool, error)
+	ParseValue(ctx context.Context, expr string) (Value, error)
+	Scope(opts ...Option) T
+	Tree(expr string) (Node, error)
+	With(opts ...Option) T
 }
 
 // parser is the implementation of the ParserInterface
 type parser struct {
-	constants    map[string]*big.Float
-	functions    map[string]func(...*big.Float) (*big.Float, error)
-	replacements map[string]string
-	variables    map[string]func() *big.Float
+	aliases     map[string][]string
+	approximate map[string]bool // names of approximate constants and variables, see WithApproximate
+	cache       *cache          // results of calls of pure functions, see WithCache
+	constants   map[string]*big.Float
+	dialect     Dialect
+	docs        map[string]Doc
+	functions   map[string]function
+	intervals   bool // whether interval arithmetic is enabled, see WithIntervals
+	limits      Limits
+	observer    func(Step)
+	parent      *parser // outer layer, see Scope
+	variables   map[string]func() Value
+	workers     int // maximum number of additional goroutines of an evaluation, see WithParallelism
 }
 
-// replace replaces variables and functions in the expression
-func (opts *parser) replace(expr string) string {
-	for k, v := range opts.replacements {
-		expr = regexp.
-			MustCompile(fmt.Sprintf(`(\b|[^a-zA-Z0-9_])%s(\b|[^a-zA-Z0-9_])`, k)).
-			ReplaceAllStringFunc(
-				expr,
-				func(match string) string {
-					if index := strings.Index(match, k); index >= 0 {
-						return match[:index] + v + match[index+len(k):]
-					}
-					return match
-				},
-			)
+// tokenize splits the expression into tokens and substitutes the aliases of the parser, see WithReplacement.
+func (opts *parser) tokenize(expr string) (*tokens, error) {
+	tokenized, err := Tokenize(expr)
+	if err != nil || len(opts.aliases) == 0 {
+		return tokenized.(*tokens), err
+	}
+
+	aliased := make(tokens, 0, tokenized.(*tokens).len())
+	for _, token := range *tokenized.(*tokens) {
+		if alias, ok := opts.aliases[token]; ok {
+			aliased = append(aliased, alias...)
+		} else {
+			aliased = append(aliased, token)
+		}
 	}
 
-	return expr
+	return &aliased, nil
 }
 
-// Apply applies the options to the parser
-func (o *parser) ApplyOptions(opts ...Option) *parser {
+// apply applies the options to the parser, which must not be shared yet.
+func (o *parser) apply(opts ...Option) *parser {
 	for _, opt := range opts {
 		if opt == nil {
 			continue
@@ -93,37 +113,126 @@ func (o *parser) ApplyOptions(opts ...Option) *parser {
 	return o
 }
 
-// LookupConst returns the value of a constant
+// ApplyOptions returns a copy of the parser with the options applied, it is the same as With.
+func (o *parser) ApplyOptions(opts ...Option) *parser {
+	return o.With(opts...)
+}
+
+// Clone returns a copy of the parser, which can be configured independently of the parser.
+// The copy starts with an empty cache of the same size, see WithCache.
+func (o *parser) Clone() *parser {
+	c := *o
+	c.aliases = maps.Clone(o.aliases)
+	c.approximate = maps.Clone(o.approximate)
+	c.cache = o.cache.empty()
+	c.constants = maps.Clone(o.constants)
+	c.docs = maps.Clone(o.docs)
+	c.functions = maps.Clone(o.functions)
+	c.variables = maps.Clone(o.variables)
+
+	return &c
+}
+
+// LookupConst returns a copy of the value of a constant
 func (opts *parser) LookupConst(name string) (*big.Float, bool) {
-	v, ok := opts.constants[name]
-	return v, ok
+	v, _ := opts.binding(name)
+	if v == nil {
+		return nil, false
+	}
+
+	return new(big.Float).Copy(v), true
 }
 
-// LookupFunc returns the function with the given name
-func (opts *parser) LookupFunc(name string) (func(...*big.Float) (*big.Float, error), bool) {
-	f, ok := opts.functions[name]
-	return f, ok
+// LookupFunc returns the function with the given name, which checks the number of its arguments
+func (opts *parser) LookupFunc(name string) (func(context.Context, ...Value) (Value, error), bool) {
+	f, ok := opts.function(name)
+	if !ok {
+		return nil, false
+	}
+
+	return f.call(name, opts), true
 }
 
 // LookupVariable returns the value of a variable
-func (opts *parser) LookupVariable(name string) (func() *big.Float, bool) {
-	v, ok := opts.variables[name]
-	return v, ok
+func (opts *parser) LookupVariable(name string) (func() Value, bool) {
+	_, v := opts.binding(name)
+	return v, v != nil
 }
 
-// Parse parses the expression and returns the result
+// Parse parses the expression and returns the result.
+// It fails if the expression does not evaluate to a number, use ParseValue to evaluate lists.
 func (opts *parser) Parse(ctx context.Context, expr string) (*big.Float, error) {
-	tokens, err := Tokenize(opts.replace(expr))
+	value, err := opts.ParseValue(ctx, expr)
 	if err != nil {
 		return nil, err
 	}
 
-	root, err := tokens.Tree()
+	result, ok := Scalar(value)
+	if !ok {
+		return nil, fmt.Errorf("expression evaluates to a list, not a number: %s", value.Text('g', 10))
+	}
+
+	return result, nil
+}
+
+// ParseExact parses the expression like ParseValue and additionally reports whether the result is exact,
+// i.e., whether it was computed without rounding, e.g., 1/4 + 2^10 is exact, but 1/3, 0.1 and √2 are not.
+// Functions and constants marked by WithApproximate, including functions backed by float64, give inexact results.
+func (opts *parser) ParseExact(ctx context.Context, expr string) (Value, bool, error) {
+	root, err := opts.Tree(expr)
 	if err != nil {
+		return nil, false, err
+	}
+
+	return root.EvaluateExact(ctx, opts)
+}
+
+// ParseValue parses the expression and returns the result, which is either a number or a list, e.g., of [1, 2, 3] * 2.
+// Arithmetic operators are applied element-wise to lists, whereas @ denotes the matrix product,
+// e.g., [[1, 2], [3, 4]] @ [1, 1] evaluates to [3, 7].
+// Tolerances are written using ±, e.g., 10 ± 0.5 evaluates to the Interval [9.5, 10.5].
+func (opts *parser) ParseValue(ctx context.Context, expr string) (Value, error) {
+	value, _, err := opts.ParseExact(ctx, expr)
+	return value, err
+}
+
+// Tree parses the expression after substituting the aliases and returns the root node of its parse tree.
+// Expressions in other dialects than the native one, see WithDialect, are translated before,
+// whereby the aliases apply to names and symbols.
+// Breaking the length, tokens or depth limit, see WithLimits, results in a LimitError,
+// whereby the parsing is aborted as soon as the depth limit is exceeded.
+func (opts *parser) Tree(expr string) (Node, error) {
+	if err := exceeded(LengthLimit, opts.limits.Length, len([]rune(expr))); err != nil {
 		return nil, err
 	}
 
-	return root.Evaluate(ctx, opts)
+	var root Node
+	if opts.dialect != NativeDialect {
+		var err error
+		if root, err = opts.translate(expr); err != nil {
+			return nil, err
+		}
+	} else {
+		tokenized, err := opts.tokenize(expr)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := exceeded(TokensLimit, opts.limits.Tokens, tokenized.len()); err != nil {
+			return nil, err
+		}
+
+		if root, err = (&builder{tokens: *tokenized, maxDepth: opts.limits.Depth}).build(); err != nil {
+			return nil, err
+		}
+	}
+
+	return root, nil
+}
+
+// With returns a copy of the parser with the options applied, the parser itself is not modified.
+func (o *parser) With(opts ...Option) *parser {
+	return o.Clone().apply(opts...)
 }
 
 // ConvertToBigFloat converts a number to a big.Float
@@ -160,15 +269,59 @@ func ConvertToBigFloat[N number](n N) (*big.Float, bool) {
 // It can be configured with options.
 // Options can be used to set variables and functions.
 // All supplied options are applied to the parser upon creation.
+// The parser is not modified afterwards, it is safe for concurrent use as long as its functions and variables are,
+// derived parsers with further options are returned by With.
 func NewParser(opts ...Option) *parser {
 	p := &parser{
-		constants:    make(map[string]*big.Float),
-		functions:    make(map[string]func(...*big.Float) (*big.Float, error)),
-		replacements: make(map[string]string),
-		variables:    make(map[string]func() *big.Float),
+		aliases:     make(map[string][]string),
+		approximate: make(map[string]bool),
+		constants:   make(map[string]*big.Float),
+		docs:        make(map[string]Doc),
+		functions:   make(map[string]function),
+		variables:   make(map[string]func() Value),
+	}
+
+	return p.apply(append([]Option{withBuiltins}, opts...)...)
+}
+
+// WithApproximate returns an option to mark functions and constants registered before as approximate,
+// e.g., PI or functions computing their results by series, whose accuracy is not reported by the results, see ParseExact.
+// Functions backed by float64 are approximate anyway, registering a function or constant again unmarks it.
+func WithApproximate(names ...string) func(*parser) {
+	return func(p *parser) {
+		for _, name := range names {
+			if f, ok := p.function(name); ok {
+				f.approximate = true
+				p.functions[name] = f
+			}
+
+			if constant, variable := p.binding(name); constant != nil || variable != nil {
+				p.approximate[name] = true
+			}
+		}
 	}
+}
 
-	return p.ApplyOptions(opts...)
+// WithArity returns an option to restrict the number of arguments of a function registered before,
+// e.g., of a variadic function, a maximum of -1 makes the function variadic.
+// Calls with a different number of arguments fail without calling the function.
+func WithArity(name string, minArgs, maxArgs int) func(*parser) {
+	return func(p *parser) {
+		if f, ok := p.function(name); ok {
+			f.minArgs, f.maxArgs = minArgs, maxArgs
+			p.functions[name] = f
+		}
+	}
+}
+
+// WithCache returns an option to cache up to size results of calls of pure functions, see WithPure,
+// evicting the least recently used results first, e.g., f(x) + f(x) calls f once.
+// Results are cached by the name of the function and the exact values of its arguments, errors are not cached.
+// A size of zero or less disables the cache (default).
+func WithCache(size int) func(*parser) {
+	return func(p *parser) {
+		p.cache = newCache(size)
+	}
 }
 
 // WithConst returns an option to set a constant
@@ -178,16 +331,76 @@ func WithConst[N number](name string, value N) func(*parser) {
 		if !ok {
 			return
 		}
-		p.constants[name] = v
+		p.constants[name] = new(big.Float).Copy(v)
+		delete(p.approximate, name)
+	}
+}
+
+// WithDoc returns an option to document a function or constant, see ListFunctions and ListConstants.
+func WithDoc(name string, doc Doc) func(*parser) {
+	return func(p *parser) {
+		p.docs[name] = doc
+	}
+}
+
+// WithDialect returns an option to set the input dialect of expressions.
+// The dialects are translated into the same parse trees as the native syntax,
+// and syntax errors report their position in the original expression.
+func WithDialect(dialect Dialect) func(*parser) {
+	return func(p *parser) {
+		p.dialect = dialect
+	}
+}
+
+// WithFactorialLimit returns an option to subject the arguments of functions registered before to the factorial limit,
+// see WithLimits, e.g., of fib or nPr, whose costs grow with their arguments like the ones of the factorial.
+// Registering a function again unmarks it.
+func WithFactorialLimit(names ...string) func(*parser) {
+	return func(p *parser) {
+		for _, name := range names {
+			if f, ok := p.function(name); ok {
+				f.factorial = true
+				p.functions[name] = f
+			}
+		}
 	}
 }
 
-// WithFunc returns an option to set a function
+// WithFunc returns an option to set a function.
+// Functions taking a context.Context as first argument receive the context of the evaluation,
+// e.g., the context passed to Parse, so that they can be cancelled.
+// The number of arguments is derived from the signature, see WithArity and ListFunctions.
+// The signature of fn determines how lists are passed to the function:
+//   - functions of fixed arity taking numbers are applied element-wise to lists,
+//     whereby numbers are combined with every element of a list (broadcasting)
+//   - variadic functions taking numbers receive all elements of lists flattened, e.g., sum([1, 2], 3)
+//   - functions taking slices of numbers receive each argument flattened into a slice, e.g., dot([1, 2], [3, 4])
+//   - functions taking matrices receive lists of rows, e.g., det([[1, 2], [3, 4]])
+//   - functions taking values receive the arguments unchanged
 func WithFunc[
 	F interface {
 		~func(...*big.Float) (*big.Float, error) |
 			~func(*big.Float) (*big.Float, error) |
+			~func(*big.Float) ([]*big.Float, error) |
 			~func(*big.Float, *big.Float) (*big.Float, error) |
+			~func([]*big.Float) (*big.Float, error) |
+			~func([]*big.Float, []*big.Float) (*big.Float, error) |
+			~func([]*big.Float, []*big.Float) ([]*big.Float, error) |
+			~func([][]*big.Float) (*big.Float, error) |
+			~func([][]*big.Float) ([][]*big.Float, error) |
+			~func([][]*big.Float, []*big.Float) ([]*big.Float, error) |
+			~func(...Value) (Value, error) |
+			~func(context.Context, ...*big.Float) (*big.Float, error) |
+			~func(context.Context, *big.Float) (*big.Float, error) |
+			~func(context.Context, *big.Float) ([]*big.Float, error) |
+			~func(context.Context, *big.Float, *big.Float) (*big.Float, error) |
+			~func(context.Context, []*big.Float) (*big.Float, error) |
+			~func(context.Context, []*big.Float, []*big.Float) (*big.Float, error) |
+			~func(context.Context, []*big.Float, []*big.Float) ([]*big.Float, error) |
+			~func(context.Context, [][]*big.Float) (*big.Float, error) |
+			~func(context.Context, [][]*big.Float) ([][]*big.Float, error) |
+			~func(context.Context, [][]*big.Float, []*big.Float) ([]*big.Float, error) |
+			~func(context.Context, ...Value) (Value, error) |
 			~func(float64) float64 |
 			~func(float64) (float64, error) |
 			~func(float64, float64) float64 |
@@ -197,94 +410,268 @@ func WithFunc[
 	return func(p *parser) {
 		switch fn := any(fn).(type) {
 		case func(...*big.Float) (*big.Float, error):
-			p.functions[name] = func(f ...*big.Float) (*big.Float, error) {
-				return fn(f...)
-			}
+			WithFunc(name, func(_ context.Context, args ...*big.Float) (*big.Float, error) { return fn(args...) })(p)
 
 		case func(*big.Float) (*big.Float, error):
-			p.functions[name] = func(args ...*big.Float) (*big.Float, error) {
-				if len(args) != 1 {
-					return nil, fmt.Errorf("%s function requires exactly 1 argument", name)
-				}
-				return fn(args[0])
-			}
+			WithFunc(name, func(_ context.Context, x *big.Float) (*big.Float, error) { return fn(x) })(p)
+
+		case func(*big.Float) ([]*big.Float, error):
+			WithFunc(name, func(_ context.Context, x *big.Float) ([]*big.Float, error) { return fn(x) })(p)
 
 		case func(*big.Float, *big.Float) (*big.Float, error):
-			p.functions[name] = func(args ...*big.Float) (*big.Float, error) {
-				if len(args) != 2 {
-					return nil, fmt.Errorf("%s function requires exactly 2 arguments", name)
+			WithFunc(name, func(_ context.Context, x, y *big.Float) (*big.Float, error) { return fn(x, y) })(p)
+
+		case func([]*big.Float) (*big.Float, error):
+			WithFunc(name, func(_ context.Context, a []*big.Float) (*big.Float, error) { return fn(a) })(p)
+
+		case func([]*big.Float, []*big.Float) (*big.Float, error):
+			WithFunc(name, func(_ context.Context, a, b []*big.Float) (*big.Float, error) { return fn(a, b) })(p)
+
+		case func([]*big.Float, []*big.Float) ([]*big.Float, error):
+			WithFunc(name, func(_ context.Context, a, b []*big.Float) ([]*big.Float, error) { return fn(a, b) })(p)
+
+		case func([][]*big.Float) (*big.Float, error):
+			WithFunc(name, func(_ context.Context, m [][]*big.Float) (*big.Float, error) { return fn(m) })(p)
+
+		case func([][]*big.Float) ([][]*big.Float, error):
+			WithFunc(name, func(_ context.Context, m [][]*big.Float) ([][]*big.Float, error) { return fn(m) })(p)
+
+		case func([][]*big.Float, []*big.Float) ([]*big.Float, error):
+			WithFunc(name, func(_ context.Context, m [][]*big.Float, b []*big.Float) ([]*big.Float, error) { return fn(m, b) })(p)
+
+		case func(...Value) (Value, error):
+			WithFunc(name, func(_ context.Context, args ...Value) (Value, error) { return fn(args...) })(p)
+
+		case func(context.Context, ...*big.Float) (*big.Float, error):
+			p.register(name, 0, -1, func(ctx context.Context, args ...Value) (Value, error) {
+				f, err := Floats(args...)
+				if err != nil {
+					return nil, err
 				}
-				return fn(args[0], args[1])
-			}
+				return fn(ctx, f...)
+			})
 
-		case func(float64) float64:
-			p.functions[name] = func(args ...*big.Float) (*big.Float, error) {
-				if len(args) != 1 {
-					return nil, fmt.Errorf("%s function requires exactly 1 argument", name)
+		case func(context.Context, *big.Float) (*big.Float, error):
+			p.register(name, 1, 1, unary(func(ctx context.Context, x *big.Float) (Value, error) { return fn(ctx, x) }))
+
+		case func(context.Context, *big.Float) ([]*big.Float, error):
+			p.register(name, 1, 1, unary(func(ctx context.Context, x *big.Float) (Value, error) {
+				r, err := fn(ctx, x)
+				if err != nil {
+					return nil, err
 				}
-				f, _ := args[0].Float64()
-				return big.NewFloat(fn(f)), nil
-			}
+				return scalars(r), nil
+			}))
 
-		case func(float64) (float64, error):
-			p.functions[name] = func(args ...*big.Float) (*big.Float, error) {
-				if len(args) != 1 {
-					return nil, fmt.Errorf("%s function requires exactly 1 argument", name)
+		case func(context.Context, *big.Float, *big.Float) (*big.Float, error):
+			p.register(name, 2, 2, binary(func(ctx context.Context, x, y *big.Float) (Value, error) { return fn(ctx, x, y) }))
+
+		case func(context.Context, []*big.Float) (*big.Float, error):
+			p.register(name, 1, 1, func(ctx context.Context, args ...Value) (Value, error) {
+				f, err := Floats(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return fn(ctx, f)
+			})
+
+		case func(context.Context, []*big.Float, []*big.Float) (*big.Float, error):
+			p.register(name, 2, 2, func(ctx context.Context, args ...Value) (Value, error) {
+				f1, f2, err := pair(args...)
+				if err != nil {
+					return nil, err
+				}
+				return fn(ctx, f1, f2)
+			})
+
+		case func(context.Context, []*big.Float, []*big.Float) ([]*big.Float, error):
+			p.register(name, 2, 2, func(ctx context.Context, args ...Value) (Value, error) {
+				f1, f2, err := pair(args...)
+				if err != nil {
+					return nil, err
+				}
+				r, err := fn(ctx, f1, f2)
+				if err != nil {
+					return nil, err
+				}
+				return scalars(r), nil
+			})
+
+		case func(context.Context, [][]*big.Float) (*big.Float, error):
+			p.register(name, 1, 1, func(ctx context.Context, args ...Value) (Value, error) {
+				m, err := Matrix(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return fn(ctx, m)
+			})
+
+		case func(context.Context, [][]*big.Float) ([][]*big.Float, error):
+			p.register(name, 1, 1, func(ctx context.Context, args ...Value) (Value, error) {
+				m, err := Matrix(args[0])
+				if err != nil {
+					return nil, err
 				}
-				f, _ := args[0].Float64()
+				r, err := fn(ctx, m)
+				if err != nil {
+					return nil, err
+				}
+				return matrix(r), nil
+			})
+
+		case func(context.Context, [][]*big.Float, []*big.Float) ([]*big.Float, error):
+			p.register(name, 2, 2, func(ctx context.Context, args ...Value) (Value, error) {
+				m, err := Matrix(args[0])
+				if err != nil {
+					return nil, err
+				}
+				f, err := Floats(args[1])
+				if err != nil {
+					return nil, err
+				}
+				r, err := fn(ctx, m, f)
+				if err != nil {
+					return nil, err
+				}
+				return scalars(r), nil
+			})
+
+		case func(context.Context, ...Value) (Value, error):
+			p.register(name, 0, -1, fn)
+
+		case func(float64) float64:
+			p.register(name, 1, 1, unary(func(_ context.Context, x *big.Float) (Value, error) {
+				f, _ := x.Float64()
+				return float(name, fn(f))
+			}))
+
+		case func(float64) (float64, error):
+			p.register(name, 1, 1, unary(func(_ context.Context, x *big.Float) (Value, error) {
+				f, _ := x.Float64()
 				r, err := fn(f)
 				if err != nil {
 					return nil, err
 				}
-				return big.NewFloat(r), nil
-			}
+				return float(name, r)
+			}))
 
 		case func(float64, float64) float64:
-			p.functions[name] = func(args ...*big.Float) (*big.Float, error) {
-				if len(args) != 2 {
-					return nil, fmt.Errorf("%s function requires exactly 2 arguments", name)
-				}
-				f1, _ := args[0].Float64()
-				f2, _ := args[1].Float64()
-				return big.NewFloat(fn(f1, f2)), nil
-			}
+			p.register(name, 2, 2, binary(func(_ context.Context, x, y *big.Float) (Value, error) {
+				f1, _ := x.Float64()
+				f2, _ := y.Float64()
+				return float(name, fn(f1, f2))
+			}))
 
 		case func(float64, float64) (float64, error):
-			p.functions[name] = func(args ...*big.Float) (*big.Float, error) {
-				if len(args) != 2 {
-					return nil, fmt.Errorf("%s function requires exactly 2 arguments", name)
-				}
-				f1, _ := args[0].Float64()
-				f2, _ := args[1].Float64()
+			p.register(name, 2, 2, binary(func(_ context.Context, x, y *big.Float) (Value, error) {
+				f1, _ := x.Float64()
+				f2, _ := y.Float64()
 				r, err := fn(f1, f2)
 				if err != nil {
 					return nil, err
 				}
-				return big.NewFloat(r), nil
-			}
+				return float(name, r)
+			}))
+
+		}
+
+		switch any(fn).(type) {
+		case func(float64) float64, func(float64) (float64, error), func(float64, float64) float64, func(float64, float64) (float64, error):
+			WithApproximate(name)(p) // the results are rounded to float64
 
 		}
 	}
 }
 
+// WithIntervals returns an option to enable interval arithmetic, e.g., for tolerance checks.
+// List literals of two numbers denote intervals then, e.g., [9.8, 9.81] * [1.9, 2.1] evaluates to an interval enclosing
+// [18.62, 20.601], and numbers not representable exactly, e.g., 9.8, as well as approximate constants, e.g., PI,
+// are enclosed by intervals, whose bounds are rounded outwards by every operation, so that the results are guaranteed.
+// The standard functions abs, arccos, arcsin, arctan, cos, exp, ln, log (base 10), sin, sqrt and tan are evaluated
+// by their interval versions regardless of the functions registered by these names.
+// Intervals of a tolerance, e.g., 9.81 ± 0.01, are available without enabling interval arithmetic.
+func WithIntervals() func(*parser) {
+	return func(p *parser) {
+		p.intervals = true
+	}
+}
+
+// WithLimits returns an option to set the resource limits of the parser against runaway expressions.
+// Expressions breaking a limit fail with a LimitError.
+func WithLimits(limits Limits) func(*parser) {
+	return func(p *parser) {
+		p.limits = limits
+	}
+}
+
+// WithObserver returns an option to observe evaluations.
+// The observer is called with each step of an evaluation in the order of evaluation,
+// the steps can be rendered as successive expressions using Rewrite.
+func WithObserver(observer func(Step)) func(*parser) {
+	return func(p *parser) {
+		p.observer = observer
+	}
+}
+
+// WithParallelism returns an option to evaluate independent operands concurrently,
+// e.g., both operands of 100000! / 99990! + fib(10^6), using up to the given number of additional goroutines per evaluation.
+// Only operands estimated to be expensive, e.g., factorials and function calls, are evaluated concurrently,
+// the functions of the parser have to be safe for concurrent use then.
+// The results and errors are the same as the ones of a sequential evaluation, i.e., the error of the leftmost failing operand
+// is returned and the remaining operands are cancelled through the context.
+// Parallel evaluation is disabled by default, by zero or less workers and for parsers with an observer, see WithObserver.
+func WithParallelism(workers int) func(*parser) {
+	return func(p *parser) {
+		p.workers = workers
+	}
+}
+
+// WithPure returns an option to mark functions registered before as pure, i.e., their results depend on their arguments only,
+// so that their calls are cached, see WithCache.
+// Functions with side effects, e.g., storing their argument, must not be marked, registering a function again unmarks it.
+func WithPure(names ...string) func(*parser) {
+	return func(p *parser) {
+		for _, name := range names {
+			if f, ok := p.function(name); ok {
+				f.pure = true
+				p.functions[name] = f
+			}
+		}
+	}
+}
+
+// WithReplacement returns an option to alias a name or a symbol by the tokens of value, e.g., π by PI.
+// Aliases are substituted for whole tokens only, hence the alias E of e neither applies to names like exp
+// nor to numbers in scientific notation like 1e-3.
 func WithReplacement(name, value string) func(*parser) {
 	return func(p *parser) {
-		p.replacements[name] = value
+		tokenized, _ := Tokenize(value)
+		p.aliases[name] = *tokenized.(*tokens)
 	}
 }
 
+// WithReplacements returns an option to set several aliases given as pairs of names and values, see WithReplacement.
 func WithReplacements(replacements ...string) func(*parser) {
 	return func(p *parser) {
-		for i := 0; i < len(replacements); i += 2 {
-			p.replacements[replacements[i]] = replacements[i+1]
+		for i := 0; i+1 < len(replacements); i += 2 {
+			WithReplacement(replacements[i], replacements[i+1])(p)
 		}
 	}
 }
 
+// WithValueVar returns an option to set a variable, which may hold a number or a list
+func WithValueVar(name string, value func() Value) func(*parser) {
+	return func(p *parser) {
+		p.variables[name] = value
+		delete(p.approximate, name)
+	}
+}
+
 // WithVar returns an option to set a variable
 func WithVar[N number](name string, value func() N) func(*parser) {
 	return func(p *parser) {
-		p.variables[name] = func() *big.Float {
+		delete(p.approximate, name)
+		p.variables[name] = func() Value {
 			v, ok := ConvertToBigFloat(value())
 			if !ok {
 				return nil
@@ -294,3 +681,82 @@ func WithVar[N number](name string, value func() N) func(*parser) {
 		}
 	}
 }
+
+// WithoutConst returns an option to remove a constant, e.g., from a parser derived by With.
+func WithoutConst(name string) func(*parser) {
+	return func(p *parser) {
+		p.constants[name] = nil // hides constants of outer layers, see Scope
+		delete(p.approximate, name)
+	}
+}
+
+// WithoutFunc returns an option to remove a function, e.g., from a parser derived by With.
+func WithoutFunc(name string) func(*parser) {
+	return func(p *parser) {
+		p.functions[name] = function{} // hides functions of outer layers, see Scope
+	}
+}
+
+// withBuiltins is an option to set the built-in functions for lists and the special form diff
+func withBuiltins(p *parser) {
+	p.register("len", 1, 1, func(_ context.Context, args ...Value) (Value, error) {
+		list, ok := args[0].(List)
+		if !ok {
+			return nil, fmt.Errorf("len function requires a list argument")
+		}
+
+		return big.NewFloat(float64(len(list))), nil
+	})
+	WithPure("len")(p)
+	p.docs["len"] = Doc{Category: "lists", Domain: "list", Description: "number of elements of a list", Examples: []string{"len([1, 2, 3])"}}
+
+	// diff is a special form, whose arguments are differentiated instead of being evaluated, see Derive.
+	// It is registered to be listed along with its documentation only.
+	p.register("diff", 2, 3, func(context.Context, ...Value) (Value, error) {
+		return nil, fmt.Errorf("diff function requires an expression rather than values")
+	})
+	p.docs["diff"] = Doc{
+		Category:    "calculus",
+		Domain:      "expression, variable and optional point",
+		Description: "derivative of an expression with respect to a variable at its current value or at a given point",
+		Examples:    []string{"diff(x^2, x, 3)", "diff(sin(x), x, 0)"},
+	}
+}
+
+// binary adapts a function of two numbers to the parser, broadcasting it over lists.
+func binary(fn func(context.Context, *big.Float, *big.Float) (Value, error)) func(context.Context, ...Value) (Value, error) {
+	return func(ctx context.Context, args ...Value) (Value, error) {
+		return broadcast(args[0], args[1], func(x, y *big.Float) (Value, error) { return fn(ctx, x, y) })
+	}
+}
+
+// float converts the result of the function backed by float64 to a value, failing if it is NaN.
+func float(name string, f float64) (Value, error) {
+	if math.IsNaN(f) {
+		return nil, fmt.Errorf("%w: result of %s function", ErrNaN, name)
+	}
+
+	return big.NewFloat(f), nil
+}
+
+// pair flattens the two arguments of a function taking two slices of numbers.
+func pair(args ...Value) ([]*big.Float, []*big.Float, error) {
+	f1, err := Floats(args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f2, err := Floats(args[1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return f1, f2, nil
+}
+
+// unary adapts a function of one number to the parser, applying it element-wise to lists.
+func unary(fn func(context.Context, *big.Float) (Value, error)) func(context.Context, ...Value) (Value, error) {
+	return func(ctx context.Context, args ...Value) (Value, error) {
+		return apply(args[0], func(x *big.Float) (Value, error) { return fn(ctx, x) })
+	}
+}
@@ -2,9 +2,17 @@ package parser
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math"
 	"math/big"
+	"runtime/debug"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/sarumaj/edu-taschenrechner/pkg/calc"
 )
 
 func TestExampleFor_Parser(t *testing.T) {
@@ -64,3 +72,436 @@ func TestExampleFor_Parser(t *testing.T) {
 		})
 	}
 }
+
+func TestExampleFor_ParserWithLists(t *testing.T) {
+	sum := WithFunc("sum", func(args ...*big.Float) (*big.Float, error) {
+		result := big.NewFloat(0)
+		for _, arg := range args {
+			result.Add(result, arg)
+		}
+		return result, nil
+	})
+	sin := WithFunc("sin", math.Sin)
+	hyp := WithFunc("hyp", math.Hypot)
+	dot := WithFunc("dot", func(a, b []*big.Float) (*big.Float, error) {
+		if len(a) != len(b) {
+			return nil, fmt.Errorf("length mismatch")
+		}
+		result := big.NewFloat(0)
+		for i := range a {
+			result.Add(result, big.NewFloat(0).Mul(a[i], b[i]))
+		}
+		return result, nil
+	})
+	divisors := WithFunc("divisors", func(n *big.Float) ([]*big.Float, error) {
+		var result []*big.Float
+		m, _ := n.Int64()
+		for i := int64(1); i <= m; i++ {
+			if m%i == 0 {
+				result = append(result, big.NewFloat(float64(i)))
+			}
+		}
+		return result, nil
+	})
+
+	type args struct {
+		expr string
+		opts []Option
+	}
+
+	for _, tt := range []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{"test#1", args{"[1, 2, 3]", nil}, "[1, 2, 3]", false},
+		{"test#2", args{"[]", nil}, "[]", false},
+		{"test#3", args{"[1, 2] + [3, 4]", nil}, "[4, 6]", false},
+		{"test#4", args{"2*[1, 2, 3]-1", nil}, "[1, 3, 5]", false},
+		{"test#5", args{"[1, 2, 3]^2", nil}, "[1, 4, 9]", false},
+		{"test#6", args{"-[1, -2]", nil}, "[-1, 2]", false},
+		{"test#7", args{"(√[4, 9])!", nil}, "[2, 6]", false},
+		{"test#8", args{"[[1, 2], [3, 4]]*[10, 100]", nil}, "[[10, 20], [300, 400]]", false},
+		{"test#9", args{"[1, 2, 3][2]", nil}, "2", false},
+		{"test#10", args{"[[1, 2], [3, 4]][2][1]", nil}, "3", false},
+		{"test#11", args{"len([1, 2, 3])", nil}, "3", false},
+		{"test#12", args{"len([])", nil}, "0", false},
+		{"test#13", args{"sum([1, 2], 3, [[4]])", []Option{sum}}, "10", false},
+		{"test#14", args{"sin([0, 0])", []Option{sin}}, "[0, 0]", false},
+		{"test#15", args{"hyp([3, 5], 4)", []Option{hyp}}, "[5, 6.403124237]", false},
+		{"test#16", args{"dot([1, 2], [3, 4])", []Option{dot}}, "11", false},
+		{"test#17", args{"divisors(12)[len(divisors(12))-1]", []Option{divisors}}, "6", false},
+		{"test#18", args{"[1, 2] + [1, 2, 3]", nil}, "", true},
+		{"test#19", args{"[1, 2][3]", nil}, "", true},
+		{"test#20", args{"[1, 2][1.5]", nil}, "", true},
+		{"test#21", args{"3[1]", nil}, "", true},
+		{"test#22", args{"len(5)", nil}, "", true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewParser(tt.args.opts...).ParseValue(context.TODO(), tt.args.expr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Error parsing expression %q: %v, want error: %t", tt.args.expr, err, tt.wantErr)
+			} else if err == nil && got.Text('g', 10) != tt.want {
+				t.Errorf("Result of %q: %s, want %s", tt.args.expr, got.Text('g', 10), tt.want)
+			}
+		})
+	}
+}
+
+func TestExampleFor_ParserSyntaxErrors(t *testing.T) {
+	p := NewParser(WithFunc("f", func(x, y float64) float64 { return x + y }))
+
+	for _, tt := range []struct {
+		name string
+		args string
+		want string
+	}{
+		{"test#1", "[1, 2", "missing closing bracket in list"},
+		{"test#2", "f(1, 2", "missing closing parenthesis in function call"},
+		{"test#3", "[f(1, 2]", "missing closing parenthesis in function call in list"},
+		{"test#4", "(1 + 2", "missing closing parenthesis"},
+		{"test#5", "[1, 2][1", "missing closing bracket in index"},
+		{"test#6", "1 +", "unexpected end of expression"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ParseValue(context.TODO(), tt.args)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Error parsing expression %q: %v, want %s", tt.args, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestExampleFor_ParserWithInfinity(t *testing.T) {
+	opts := []Option{
+		WithFunc("sin", math.Sin),
+		WithFunc("ln", math.Log),
+		WithFunc("sum", func(args ...*big.Float) (*big.Float, error) {
+			result := big.NewFloat(0)
+			for _, arg := range args {
+				result.Add(result, arg)
+			}
+			return result, nil
+		}),
+	}
+
+	for _, tt := range []struct {
+		name    string
+		args    string
+		want    string
+		wantNaN bool
+	}{
+		{"test#1", "1/Inf", "0", false},
+		{"test#2", "Inf + 1", "∞", false},
+		{"test#3", "-Inf*2", "-∞", false},
+		{"test#4", "[1, Inf]*[2, -3]", "[2, -∞]", false},
+		{"test#5", "2^Inf + 0.5^Inf", "∞", false},
+		{"test#6", "Inf! + √Inf", "∞", false},
+		{"test#7", "ln(0)", "-∞", false},
+		{"test#8", "Inf - Inf", "", true},
+		{"test#9", "0*Inf", "", true},
+		{"test#10", "Inf/Inf + 1", "", true},
+		{"test#11", "sin(Inf)", "", true},
+		{"test#12", "[1, Inf] - [1, Inf]", "", true},
+		{"test#13", "sum(ln(0), Inf)", "", true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewParser(opts...).ParseValue(context.TODO(), tt.args)
+			switch {
+			case tt.wantNaN && !errors.Is(err, ErrNaN):
+				t.Errorf("Error parsing expression %q: %v, want %v", tt.args, err, ErrNaN)
+
+			case !tt.wantNaN && err != nil:
+				t.Errorf("Error parsing expression %q: %v", tt.args, err)
+
+			case !tt.wantNaN && Text(got, 'g', 10) != tt.want:
+				t.Errorf("Result of %q: %s, want %s", tt.args, Text(got, 'g', 10), tt.want)
+
+			}
+		})
+	}
+}
+
+func TestExampleFor_ParserWithContextFunc(t *testing.T) {
+	type key struct{}
+	opts := []Option{
+		WithFunc("wait", func(ctx context.Context, x *big.Float) (*big.Float, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}),
+		WithFunc("scale", func(ctx context.Context, x *big.Float) (*big.Float, error) {
+			return big.NewFloat(0).Mul(x, ctx.Value(key{}).(*big.Float)), nil
+		}),
+		WithFunc("count", func(ctx context.Context, args ...Value) (Value, error) {
+			return big.NewFloat(float64(len(args))), ctx.Err()
+		}),
+	}
+
+	for _, tt := range []struct {
+		name    string
+		args    string
+		want    string
+		wantErr error
+	}{
+		{"test#1", "scale(2) + 1", "7", nil},
+		{"test#2", "scale([1, 2])", "[3, 6]", nil},
+		{"test#3", "count(1, [2, 3])", "2", nil},
+		{"test#4", "1 + wait(2)", "", context.DeadlineExceeded},
+		{"test#5", "scale(wait([1, 2]))", "", context.DeadlineExceeded},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.WithValue(context.TODO(), key{}, big.NewFloat(3)), 10*time.Millisecond)
+			defer cancel()
+
+			got, err := NewParser(opts...).ParseValue(ctx, tt.args)
+			switch {
+			case tt.wantErr != nil && !errors.Is(err, tt.wantErr):
+				t.Errorf("Error parsing expression %q: %v, want %v", tt.args, err, tt.wantErr)
+
+			case tt.wantErr == nil && err != nil:
+				t.Errorf("Error parsing expression %q: %v", tt.args, err)
+
+			case tt.wantErr == nil && Text(got, 'g', 10) != tt.want:
+				t.Errorf("Result of %q: %s, want %s", tt.args, Text(got, 'g', 10), tt.want)
+
+			}
+		})
+	}
+}
+
+func TestExampleFor_ParserWith(t *testing.T) {
+	base := NewParser(WithConst("PI", math.Pi), WithFunc("sin", math.Sin), WithVar("x", func() float64 { return 2 }))
+	derived := base.With(WithConst("x0", 1), WithoutConst("PI"), WithoutFunc("sin"), WithFunc("cos", math.Cos))
+	clone := base.Clone().ApplyOptions(WithConst("PI", 3))
+
+	for _, tt := range []struct {
+		name    string
+		parser  *parser
+		args    string
+		want    string
+		wantErr bool
+	}{
+		{"test#1", base, "sin(PI/2) + x", "3", false},
+		{"test#2", base, "cos", "", true},
+		{"test#3", base, "x0", "", true},
+		{"test#4", derived, "cos(0) + x0 + x", "4", false},
+		{"test#5", derived, "sin", "", true},
+		{"test#6", derived, "PI", "", true},
+		{"test#7", clone, "PI + sin(0)", "3", false},
+		{"test#8", base, "PI", "3.141592654", false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parser.Parse(context.TODO(), tt.args)
+			switch {
+			case tt.wantErr && err == nil:
+				t.Errorf("Result of %q: %v, want error", tt.args, got)
+
+			case !tt.wantErr && err != nil:
+				t.Errorf("Error parsing expression %q: %v", tt.args, err)
+
+			case !tt.wantErr && got.Text('g', 10) != tt.want:
+				t.Errorf("Result of %q: %s, want %s", tt.args, got.Text('g', 10), tt.want)
+
+			}
+		})
+	}
+
+	if got, _ := base.Parse(context.TODO(), "PI"); got.SetInt64(0) == nil || base.constants["PI"].Sign() == 0 {
+		t.Errorf("Constant PI modified through the result of an evaluation")
+	}
+
+	if _, ok := base.LookupFunc("sin"); !ok {
+		t.Errorf("Function sin removed from the base parser")
+	}
+
+	if _, ok := derived.LookupFunc("sin"); ok {
+		t.Errorf("Function sin not removed from the derived parser")
+	}
+}
+
+func TestParseConcurrently(t *testing.T) {
+	p := NewParser(WithConst("PI", math.Pi), WithFunc("sin", math.Sin), WithVar("x", func() float64 { return 2 }))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			q := p
+			if i%2 == 0 { // derive parsers while others are evaluating
+				q = p.With(WithConst("y", i), WithoutFunc("sin"), WithFunc("sin", math.Sin))
+			}
+
+			got, err := q.Parse(context.TODO(), "sin(PI/2)*x + [1, 2][2]")
+			switch {
+			case err != nil:
+				errs <- err
+
+			case got.Text('g', 10) != "4":
+				errs <- fmt.Errorf("result %s, want 4", got.Text('g', 10))
+
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("Error parsing concurrently: %v", err)
+	}
+}
+
+func TestExampleFor_ParserWithReplacements(t *testing.T) {
+	p := NewParser(
+		WithConst("E", math.E),
+		WithConst("PI", math.Pi),
+		WithFunc("exp", math.Exp),
+		WithVar("x_e", func() float64 { return 2 }),
+		WithReplacements("×", "*", "÷", "/", "π", "PI", "e", "E", "sq", "^2"),
+	)
+
+	for _, tt := range []struct {
+		name string
+		args string
+		want string
+	}{
+		{"test#1", "2×3÷4", "1.5"},
+		{"test#2", "π÷PI", "1"},
+		{"test#3", "e - E", "0"},
+		{"test#4", "exp(1) - e", "0"},
+		{"test#5", "1e-3 + 2.5E2", "250.001"},
+		{"test#6", "x_e×1e1", "20"},
+		{"test#7", "3sq", "9"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.Parse(context.TODO(), tt.args)
+			if err != nil {
+				t.Errorf("Error parsing expression %q: %v", tt.args, err)
+			} else if got.Text('g', 10) != tt.want {
+				t.Errorf("Result of %q: %s, want %s", tt.args, got.Text('g', 10), tt.want)
+			}
+		})
+	}
+}
+
+func BenchmarkTree(b *testing.B) {
+	expr := "2×sin(π/6) + e^2 - [1, 2, 3][2]÷x_1 + 1.5e-3"
+	for _, bb := range []struct {
+		name string
+		opts []Option
+	}{
+		{"without replacements", nil},
+		{"with replacements", []Option{WithReplacements("×", "*", "÷", "/", "π", "PI", "e", "E")}},
+	} {
+		p := NewParser(bb.opts...)
+		b.Run(bb.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := p.Tree(expr); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestParseDeeplyNested(t *testing.T) {
+	// the expressions are parsed and evaluated without recursion, hence a small stack suffices
+	defer debug.SetMaxStack(debug.SetMaxStack(1 << 20))
+
+	for _, tt := range []struct {
+		name string
+		args string
+		want string
+	}{
+		{"test#1", strings.Repeat("(", 100000) + "1" + strings.Repeat(")", 100000), "1"},
+		{"test#2", strings.Repeat("1+(", 100000) + "1" + strings.Repeat(")", 100000), "100001"},
+		{"test#3", strings.Repeat("-", 100001) + "1", "-1"},
+		{"test#4", strings.Repeat("[", 100000) + "2" + strings.Repeat("][1]", 100000), "2"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewParser().Parse(context.TODO(), tt.args)
+			if err != nil {
+				t.Errorf("Error parsing deeply nested expression: %v", err)
+			} else if got.Text('g', 10) != tt.want {
+				t.Errorf("Result of deeply nested expression: %s, want %s", got.Text('g', 10), tt.want)
+			}
+		})
+	}
+}
+
+func BenchmarkParseLarge(b *testing.B) {
+	for _, bb := range []struct {
+		name string
+		expr string
+	}{
+		{"sum of 4 MB", strings.Repeat("1.5+", 1<<20) + "1"},
+		{"list of 4 MB", "len([" + strings.Repeat("1.5,", 1<<20) + "1])"},
+		{"brackets of 2 MB", strings.Repeat("(", 1<<20) + "1" + strings.Repeat(")", 1<<20)},
+		{"nested sum of 3 MB", strings.Repeat("1+(", 1<<20) + "1" + strings.Repeat(")", 1<<20)},
+	} {
+		p := NewParser()
+		b.Run(bb.name, func(b *testing.B) {
+			b.SetBytes(int64(len(bb.expr)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := p.ParseValue(context.TODO(), bb.expr); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRejectsLists(t *testing.T) {
+	if got, err := NewParser().Parse(context.TODO(), "[1, 2]"); err == nil {
+		t.Errorf("Parse() = %v, want error", got)
+	}
+}
+
+func TestExampleFor_ParserWithMatrices(t *testing.T) {
+	det := WithFunc("det", func(a [][]*big.Float) (*big.Float, error) { return calc.Determinant(context.TODO(), a) })
+	inv := WithFunc("inv", func(a [][]*big.Float) ([][]*big.Float, error) { return calc.Inverse(context.TODO(), a) })
+	solve := WithFunc("solve", func(a [][]*big.Float, b []*big.Float) ([]*big.Float, error) {
+		return calc.Solve(context.TODO(), a, b)
+	})
+
+	type args struct {
+		expr string
+		opts []Option
+	}
+
+	for _, tt := range []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{"test#1", args{"[[1, 2], [3, 4]] @ [[5, 6], [7, 8]]", nil}, "[[19, 22], [43, 50]]", false},
+		{"test#2", args{"[[1, 2], [3, 4]] @ [1, 1]", nil}, "[3, 7]", false},
+		{"test#3", args{"[1, 1] @ [[1, 2], [3, 4]]", nil}, "[4, 6]", false},
+		{"test#4", args{"[1, 2, 3] @ [4, 5, 6]", nil}, "32", false},
+		{"test#5", args{"2 * [[1, 0], [0, 1]] @ [[1, 2], [3, 4]] + 1", nil}, "[[3, 5], [7, 9]]", false},
+		{"test#6", args{"det([[1, 2], [3, 4]])", []Option{det}}, "-2", false},
+		{"test#7", args{"inv([[2, 0], [0, 4]])", []Option{inv}}, "[[0.5, 0], [0, 0.25]]", false},
+		{"test#8", args{"solve([[2, 1], [1, 3]], [3, 5])", []Option{solve}}, "[0.8, 1.4]", false},
+		{"test#9", args{"[[1, 2], [3, 4]] @ inv([[1, 2], [3, 4]])", []Option{inv}}, "[[1, 0], [0, 1]]", false},
+		{"test#10", args{"[[1, 2], [3, 4]] @ [1, 2, 3]", nil}, "", true},
+		{"test#11", args{"2 @ [1, 2]", nil}, "", true},
+		{"test#12", args{"det([[1, 2], [3]])", []Option{det}}, "", true},
+		{"test#13", args{"det([[1, [2]], [3, 4]])", []Option{det}}, "", true},
+		{"test#14", args{"inv([[1, 2], [2, 4]])", []Option{inv}}, "", true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewParser(tt.args.opts...).ParseValue(context.TODO(), tt.args.expr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Error parsing expression %q: %v, want error: %t", tt.args.expr, err, tt.wantErr)
+			} else if err == nil && got.Text('g', 10) != tt.want {
+				t.Errorf("Result of %q: %s, want %s", tt.args.expr, got.Text('g', 10), tt.want)
+			}
+		})
+	}
+}
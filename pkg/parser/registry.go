@@ -0,0 +1,151 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// Doc documents a function or constant of the parser, see WithDoc.
+type Doc struct {
+	Category    string   // category, e.g., statistics
+	Domain      string   // admissible arguments of a function, e.g., x > 0
+	Description string   // one-line description
+	Examples    []string // example expressions, e.g., sum(1, 2, 3)
+}
+
+// Function describes a function of the parser, see ListFunctions.
+type Function struct {
+	Name        string
+	MinArgs     int  // minimum number of arguments
+	MaxArgs     int  // maximum number of arguments, -1 if the function is variadic
+	Pure        bool // whether the results depend on the arguments only and are cached, see WithPure
+	Approximate bool // whether the results are approximate, see WithApproximate
+	Doc
+}
+
+// Signature returns the signature of the function, e.g., sum(x1, x2, ...) or atan2(x1, x2).
+func (f Function) Signature() string {
+	args := ""
+	for i := 1; i <= max(f.MinArgs, f.MaxArgs); i++ {
+		if i > 1 {
+			args += ", "
+		}
+		if i > f.MinArgs {
+			args += "["
+		}
+		args += fmt.Sprintf("x%d", i)
+		if i > f.MinArgs {
+			args += "]"
+		}
+	}
+
+	if f.MaxArgs < 0 {
+		if args != "" {
+			args += ", "
+		}
+		args += "..."
+	}
+
+	return f.Name + "(" + args + ")"
+}
+
+// Constant describes a constant of the parser, see ListConstants.
+type Constant struct {
+	Name        string
+	Value       *big.Float
+	Approximate bool // whether the value is approximate, see WithApproximate
+	Doc
+}
+
+// function is a function registered in the parser along with its arity.
+type function struct {
+	fn          func(context.Context, ...Value) (Value, error)
+	minArgs     int
+	maxArgs     int  // -1 if variadic
+	pure        bool // see WithPure
+	approximate bool // see WithApproximate
+	factorial   bool // whether the arguments are subject to the factorial limit, see WithFactorialLimit
+}
+
+// call returns the function checking the number of its arguments before calling it.
+// The arguments of functions marked by WithFactorialLimit are checked against the factorial limit of the parser.
+// The results of pure functions are looked up in the cache of the parser first and cached after successful calls.
+func (f function) call(name string, p *parser) func(context.Context, ...Value) (Value, error) {
+	return func(ctx context.Context, args ...Value) (Value, error) {
+		switch n := len(args); {
+		case f.minArgs == f.maxArgs && n != f.minArgs:
+			return nil, fmt.Errorf("%s function requires exactly %s", name, plural(f.minArgs, "argument"))
+
+		case n < f.minArgs && f.maxArgs < 0:
+			return nil, fmt.Errorf("%s function requires at least %s", name, plural(f.minArgs, "argument"))
+
+		case n < f.minArgs, f.maxArgs >= 0 && n > f.maxArgs:
+			return nil, fmt.Errorf("%s function requires %d to %d arguments", name, f.minArgs, f.maxArgs)
+
+		}
+
+		if f.factorial {
+			if err := p.factorial(args...); err != nil {
+				return nil, err
+			}
+		}
+
+		if !f.pure || p.cache == nil {
+			return f.fn(ctx, args...)
+		}
+
+		key := callKey(name, args)
+		if result, ok := p.cache.get(key); ok {
+			return result, nil
+		}
+
+		result, err := f.fn(ctx, args...)
+		if err == nil {
+			p.cache.put(key, result)
+		}
+
+		return result, err
+	}
+}
+
+// ListConstants returns the constants of the parser sorted by name.
+func (p *parser) ListConstants() []Constant {
+	var constants []Constant
+	p.visible(func(name string, constant *big.Float, _ func() Value, _ *function) {
+		if constant != nil {
+			constants = append(constants, Constant{Name: name, Value: constant, Approximate: p.approximated(name), Doc: p.docs[name]})
+		}
+	})
+
+	sort.Slice(constants, func(i, j int) bool { return constants[i].Name < constants[j].Name })
+	return constants
+}
+
+// ListFunctions returns the functions of the parser sorted by name.
+func (p *parser) ListFunctions() []Function {
+	var functions []Function
+	p.visible(func(name string, _ *big.Float, _ func() Value, f *function) {
+		if f != nil {
+			functions = append(functions, Function{Name: name, MinArgs: f.minArgs, MaxArgs: f.maxArgs, Pure: f.pure, Approximate: f.approximate, Doc: p.docs[name]})
+		}
+	})
+
+	sort.Slice(functions, func(i, j int) bool { return functions[i].Name < functions[j].Name })
+	return functions
+}
+
+// register registers the function with the given arity, a maximum of -1 makes the function variadic.
+func (p *parser) register(name string, minArgs, maxArgs int, fn func(context.Context, ...Value) (Value, error)) {
+	p.functions[name] = function{fn: fn, minArgs: minArgs, maxArgs: maxArgs}
+}
+
+// plural returns the count followed by the noun, which is pluralized unless the count is 1.
+func plural(count int, noun string) string {
+	if count == 1 {
+		return "1 " + noun
+	}
+
+	return fmt.Sprintf("%d %ss", count, noun)
+}
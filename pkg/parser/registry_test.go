@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExampleFor_ParserListFunctions(t *testing.T) {
+	p := NewParser(
+		WithFunc("sin", math.Sin),
+		WithFunc("atan2", math.Atan2),
+		WithFunc("sum", func(args ...*big.Float) (*big.Float, error) { return args[0], nil }),
+		WithFunc("dot", func(a, b []*big.Float) (*big.Float, error) { return a[0], nil }),
+		WithFunc("clamp", func(args ...Value) (Value, error) { return args[0], nil }),
+		WithArity("clamp", 1, 3),
+		WithFunc("quantile", func(args ...*big.Float) (*big.Float, error) { return args[0], nil }),
+		WithArity("quantile", 2, -1),
+		WithArity("undefined", 1, 1),
+		WithDoc("sin", Doc{Category: "trigonometry", Description: "sine of an angle in radians", Examples: []string{"sin(PI/2)"}}),
+		WithConst("PI", math.Pi),
+		WithDoc("PI", Doc{Category: "constants", Description: "ratio of circumference to diameter"}),
+		WithConst("E", math.E),
+	)
+
+	for _, tt := range []struct {
+		name      string
+		function  string
+		want      Function
+		signature string
+	}{
+		{"test#1", "atan2", Function{Name: "atan2", MinArgs: 2, MaxArgs: 2, Approximate: true}, "atan2(x1, x2)"},
+		{"test#2", "clamp", Function{Name: "clamp", MinArgs: 1, MaxArgs: 3}, "clamp(x1, [x2], [x3])"},
+		{"test#3", "dot", Function{Name: "dot", MinArgs: 2, MaxArgs: 2}, "dot(x1, x2)"},
+		{"test#4", "len", Function{Name: "len", MinArgs: 1, MaxArgs: 1, Pure: true, Doc: Doc{
+			Category: "lists", Domain: "list", Description: "number of elements of a list", Examples: []string{"len([1, 2, 3])"},
+		}}, "len(x1)"},
+		{"test#5", "quantile", Function{Name: "quantile", MinArgs: 2, MaxArgs: -1}, "quantile(x1, x2, ...)"},
+		{"test#6", "sin", Function{Name: "sin", MinArgs: 1, MaxArgs: 1, Approximate: true, Doc: Doc{
+			Category: "trigonometry", Description: "sine of an angle in radians", Examples: []string{"sin(PI/2)"},
+		}}, "sin(x1)"},
+		{"test#7", "sum", Function{Name: "sum", MinArgs: 0, MaxArgs: -1}, "sum(...)"},
+		{"test#8", "diff", Function{Name: "diff", MinArgs: 2, MaxArgs: 3, Doc: Doc{
+			Category:    "calculus",
+			Domain:      "expression, variable and optional point",
+			Description: "derivative of an expression with respect to a variable at its current value or at a given point",
+			Examples:    []string{"diff(x^2, x, 3)", "diff(sin(x), x, 0)"},
+		}}, "diff(x1, x2, [x3])"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *Function
+			for _, f := range p.ListFunctions() {
+				if f := f; f.Name == tt.function {
+					got = &f
+				}
+			}
+
+			switch {
+			case got == nil:
+				t.Errorf("Function %s not listed", tt.function)
+
+			case !reflect.DeepEqual(*got, tt.want):
+				t.Errorf("Function %s: %+v, want %+v", tt.function, *got, tt.want)
+
+			case got.Signature() != tt.signature:
+				t.Errorf("Signature of %s: %s, want %s", tt.function, got.Signature(), tt.signature)
+
+			}
+		})
+	}
+
+	var names []string
+	for _, f := range p.ListFunctions() {
+		names = append(names, f.Name)
+	}
+	if want := "atan2, clamp, diff, dot, len, quantile, sin, sum"; strings.Join(names, ", ") != want {
+		t.Errorf("ListFunctions: %s, want %s", strings.Join(names, ", "), want)
+	}
+
+	constants := p.ListConstants()
+	if len(constants) != 2 || constants[0].Name != "E" || constants[1].Name != "PI" ||
+		constants[1].Description != "ratio of circumference to diameter" || constants[1].Value.Text('g', 5) != "3.1416" {
+		t.Errorf("ListConstants: %+v", constants)
+	}
+}
+
+func TestExampleFor_ParserWithArity(t *testing.T) {
+	p := NewParser(
+		WithFunc("sin", math.Sin),
+		WithFunc("clamp", func(args ...Value) (Value, error) { return args[0], nil }),
+		WithArity("clamp", 1, 3),
+		WithFunc("quantile", func(args ...*big.Float) (*big.Float, error) { return args[0], nil }),
+		WithArity("quantile", 2, -1),
+	)
+
+	for _, tt := range []struct {
+		name string
+		args string
+		want string
+	}{
+		{"test#1", "sin(1, 2)", "sin function requires exactly 1 argument"},
+		{"test#2", "clamp(1, 2, 3, 4)", "clamp function requires 1 to 3 arguments"},
+		{"test#3", "quantile(1)", "quantile function requires at least 2 arguments"},
+		{"test#4", "len([1], [2])", "len function requires exactly 1 argument"},
+		{"test#5", "clamp(1, 2) + quantile(3, 4, 5)", ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.Parse(context.TODO(), tt.args)
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("Error parsing expression %q: %v", tt.args, err)
+
+			case tt.want != "" && (err == nil || err.Error() != tt.want):
+				t.Errorf("Error parsing expression %q: %v, want %q", tt.args, err, tt.want)
+
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package parser
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sarumaj/edu-taschenrechner/pkg/runes"
+)
+
+// operators and brackets of the native syntax
+const (
+	operators = "+-±*/@^!°√"
+	brackets  = "()[]"
+)
+
+// Kind is the kind of a token, see Token.
+type Kind int
+
+// kinds of tokens
+const (
+	NumberToken     Kind = iota + 1 // number, e.g., 2.5 or 1.5e-3
+	IdentifierToken                 // name of a constant, variable or function, e.g., x_1
+	OperatorToken                   // operator, i.e., + - ± * / @ ^ ! ° √
+	BracketToken                    // parenthesis or bracket, i.e., ( ) [ ]
+	SeparatorToken                  // separator of arguments and elements, i.e., a comma
+	SymbolToken                     // any other symbol, e.g., × to be aliased, see WithReplacement
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case NumberToken:
+		return "number"
+
+	case IdentifierToken:
+		return "identifier"
+
+	case OperatorToken:
+		return "operator"
+
+	case BracketToken:
+		return "bracket"
+
+	case SeparatorToken:
+		return "separator"
+
+	case SymbolToken:
+		return "symbol"
+
+	}
+
+	return "unknown"
+}
+
+// Token is a token of an expression in the native syntax along with its position.
+// The offsets count runes, not bytes, e.g., the token 2 of √2 starts at 1.
+type Token struct {
+	Kind  Kind
+	Text  string
+	Start int // offset of the first rune of the token
+	End   int // offset after the last rune of the token
+}
+
+// Scanner reads the tokens of an expression one after another, e.g.,
+//
+//	scanner := parser.NewScanner(strings.NewReader("2*x"))
+//	for scanner.Scan() {
+//		fmt.Println(scanner.Token())
+//	}
+//
+// The tokens are the same as the ones of Tokenize.
+type Scanner struct {
+	reader *bufio.Reader
+	ahead  []rune // runes read ahead
+	pos    int    // offset of the next rune
+	token  Token  // current token
+	err    error  // first error other than io.EOF
+}
+
+// NewScanner returns a scanner reading the expression from r.
+func NewScanner(r io.Reader) *Scanner {
+	return &Scanner{reader: bufio.NewReader(r)}
+}
+
+// Err returns the first error, which is not io.EOF, encountered by the scanner.
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// Scan advances the scanner to the next token, which is then available through Token.
+// It returns false at the end of the expression or if reading it failed, see Err.
+func (s *Scanner) Scan() bool {
+	for ch, ok := s.peek(0); ok && unicode.IsSpace(ch); ch, ok = s.peek(0) { // Skip whitespace
+		s.next()
+	}
+
+	start := s.pos
+	ch, ok := s.next()
+	if !ok {
+		return false
+	}
+
+	s.token = Token{Start: start}
+	switch {
+	case runes.IsDigit(ch), ch == '.': // Handle numbers (including floating point and scientific notation)
+		s.token.Kind = NumberToken
+		var text strings.Builder
+		text.WriteRune(ch)
+		s.accept(&text, func(ch rune) bool { return runes.IsDigit(ch) || ch == '.' })
+		if s.exponent() {
+			e, _ := s.next()
+			text.WriteRune(e)
+			if sign, _ := s.peek(0); runes.IsAnyOf(sign, "+-") {
+				s.next()
+				text.WriteRune(sign)
+			}
+			s.accept(&text, runes.IsDigit)
+		}
+		s.token.Text = text.String()
+
+	case runes.IsLetter(ch), ch == '_': // Handle names of variables, functions or units
+		s.token.Kind = IdentifierToken
+		var text strings.Builder
+		text.WriteRune(ch)
+		s.accept(&text, runes.IsWord)
+		s.token.Text = text.String()
+
+	case strings.ContainsRune(operators, ch):
+		s.token.Kind, s.token.Text = OperatorToken, symbol(operators, ch)
+
+	case strings.ContainsRune(brackets, ch):
+		s.token.Kind, s.token.Text = BracketToken, symbol(brackets, ch)
+
+	case ch == ',':
+		s.token.Kind, s.token.Text = SeparatorToken, ","
+
+	default:
+		s.token.Kind, s.token.Text = SymbolToken, string(ch)
+
+	}
+
+	s.token.End = s.pos
+	return true
+}
+
+// Token returns the current token, see Scan.
+func (s *Scanner) Token() Token {
+	return s.token
+}
+
+// accept consumes the runes for which accept returns true and writes them to text.
+func (s *Scanner) accept(text *strings.Builder, accept func(rune) bool) {
+	for ch, ok := s.peek(0); ok && accept(ch); ch, ok = s.peek(0) {
+		text.WriteRune(ch)
+		s.next()
+	}
+}
+
+// exponent reports whether the exponent of a number in scientific notation follows, e.g., e-3.
+func (s *Scanner) exponent() bool {
+	if ch, ok := s.peek(0); !ok || !runes.IsAnyOf(ch, "eE") {
+		return false
+	}
+
+	ch, ok := s.peek(1)
+	if ok && runes.IsAnyOf(ch, "+-") {
+		ch, ok = s.peek(2)
+	}
+
+	return ok && runes.IsDigit(ch)
+}
+
+// next consumes the next rune.
+func (s *Scanner) next() (rune, bool) {
+	ch, ok := s.peek(0)
+	if ok {
+		s.ahead = s.ahead[1:]
+		s.pos++
+	}
+
+	return ch, ok
+}
+
+// peek returns the rune n positions ahead without consuming it.
+func (s *Scanner) peek(n int) (rune, bool) {
+	for len(s.ahead) <= n {
+		ch, _, err := s.reader.ReadRune()
+		if err != nil {
+			if err != io.EOF && s.err == nil {
+				s.err = err
+			}
+			return 0, false
+		}
+
+		s.ahead = append(s.ahead, ch)
+	}
+
+	return s.ahead[n], true
+}
+
+// symbol returns the rune ch of the set as a substring of the set, which saves allocating a string for it.
+func symbol(set string, ch rune) string {
+	i := strings.IndexRune(set, ch)
+	return set[i : i+utf8.RuneLen(ch)]
+}
+
+// Lex returns the tokens of the expression along with their kinds and positions.
+func Lex(expr string) []Token {
+	var tokens []Token
+	for scanner := NewScanner(strings.NewReader(expr)); scanner.Scan(); {
+		tokens = append(tokens, scanner.Token())
+	}
+
+	return tokens
+}
@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestExampleFor_Lex(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		args string
+		want []Token
+	}{
+		{"test#1", "2.5*x_1", []Token{
+			{NumberToken, "2.5", 0, 3}, {OperatorToken, "*", 3, 4}, {IdentifierToken, "x_1", 4, 7},
+		}},
+		{"test#2", " √( 1e-3 )", []Token{
+			{OperatorToken, "√", 1, 2}, {BracketToken, "(", 2, 3}, {NumberToken, "1e-3", 4, 8}, {BracketToken, ")", 9, 10},
+		}},
+		{"test#3", "f([1,2])°", []Token{
+			{IdentifierToken, "f", 0, 1}, {BracketToken, "(", 1, 2}, {BracketToken, "[", 2, 3}, {NumberToken, "1", 3, 4},
+			{SeparatorToken, ",", 4, 5}, {NumberToken, "2", 5, 6}, {BracketToken, "]", 6, 7}, {BracketToken, ")", 7, 8},
+			{OperatorToken, "°", 8, 9},
+		}},
+		{"test#4", "2×π\n", []Token{{NumberToken, "2", 0, 1}, {SymbolToken, "×", 1, 2}, {SymbolToken, "π", 2, 3}}},
+		{"test#5", "2e+x", []Token{
+			{NumberToken, "2", 0, 1}, {IdentifierToken, "e", 1, 2}, {OperatorToken, "+", 2, 3}, {IdentifierToken, "x", 3, 4},
+		}},
+		{"test#6", "", nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Lex(tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lex(%q): %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScannerReaderError(t *testing.T) {
+	failure := errors.New("failure")
+	scanner := NewScanner(io.MultiReader(strings.NewReader("1 + x"), iotest.ErrReader(failure)))
+
+	var texts []string
+	for scanner.Scan() {
+		texts = append(texts, scanner.Token().Text)
+	}
+
+	if strings.Join(texts, " ") != "1 + x" || !errors.Is(scanner.Err(), failure) {
+		t.Errorf("Scanned %q, %v, want %q, %v", texts, scanner.Err(), "1 + x", failure)
+	}
+
+	if NumberToken.String() != "number" || Kind(0).String() != "unknown" {
+		t.Errorf("Names of kinds: %s, %s", NumberToken, Kind(0))
+	}
+}
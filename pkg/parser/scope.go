@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"maps"
+	"math/big"
+)
+
+// Scope returns a new parser layered on top of the parser with the options applied to the new layer,
+// e.g., a per-session layer on top of a parser holding the standard library.
+// Constants, variables and functions are looked up in the new layer first and then in the parser,
+// hence the new layer shadows definitions of the same name, and WithoutConst and WithoutFunc hide them.
+// The definitions of the parser are neither copied nor modified, it can be shared by any number of layers.
+func (p *parser) Scope(opts ...Option) *parser {
+	layer := &parser{
+		aliases:     maps.Clone(p.aliases),
+		approximate: make(map[string]bool),
+		cache:       p.cache.empty(),
+		constants:   make(map[string]*big.Float),
+		dialect:     p.dialect,
+		docs:        maps.Clone(p.docs),
+		functions:   make(map[string]function),
+		intervals:   p.intervals,
+		limits:      p.limits,
+		observer:    p.observer,
+		parent:      p,
+		variables:   make(map[string]func() Value),
+		workers:     p.workers,
+	}
+
+	return layer.apply(opts...)
+}
+
+// binding returns the constant or variable bound to the name by the innermost layer defining it.
+// Both are nil if the name is unbound or hidden by WithoutConst.
+func (p *parser) binding(name string) (*big.Float, func() Value) {
+	for layer := p; layer != nil; layer = layer.parent {
+		if constant, ok := layer.constants[name]; ok {
+			return constant, nil
+		}
+
+		if variable, ok := layer.variables[name]; ok {
+			return nil, variable
+		}
+	}
+
+	return nil, nil
+}
+
+// function returns the function of the name defined by the innermost layer defining it.
+// It fails if there is none or it is hidden by WithoutFunc.
+func (p *parser) function(name string) (function, bool) {
+	for layer := p; layer != nil; layer = layer.parent {
+		if f, ok := layer.functions[name]; ok {
+			return f, f.fn != nil
+		}
+	}
+
+	return function{}, false
+}
+
+// visible calls fn with each constant, variable and function name visible in the parser,
+// i.e., not shadowed by a definition of the same kind in an inner layer nor hidden.
+func (p *parser) visible(fn func(name string, constant *big.Float, variable func() Value, f *function)) {
+	values, functions := make(map[string]bool), make(map[string]bool)
+	for layer := p; layer != nil; layer = layer.parent {
+		for name, constant := range layer.constants {
+			if !values[name] && constant != nil {
+				fn(name, constant, nil, nil)
+			}
+			values[name] = true
+		}
+
+		for name, variable := range layer.variables {
+			if !values[name] {
+				fn(name, nil, variable, nil)
+			}
+			values[name] = true
+		}
+
+		for name, f := range layer.functions {
+			if !functions[name] && f.fn != nil {
+				f := f
+				fn(name, nil, nil, &f)
+			}
+			functions[name] = true
+		}
+	}
+}
+
+// withVariable returns a layer on top of the parser, in which name refers to the given value.
+// A constant of the same name is shadowed by the variable.
+func (p *parser) withVariable(name string, value Value) *parser {
+	return p.Scope(WithValueVar(name, func() Value { return value }))
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestExampleFor_ParserScope(t *testing.T) {
+	library := NewParser(
+		WithConst("PI", math.Pi),
+		WithConst("x", 10),
+		WithFunc("sin", math.Sin),
+		WithFunc("sqr", func(x float64) float64 { return x * x }),
+	)
+	user := library.Scope(
+		WithVar("x", func() float64 { return 2 }),
+		WithFunc("sqr", func(x float64) float64 { return -x * x }),
+		WithConst("k", 3),
+	)
+	evaluation := user.Scope(WithConst("k", 4), WithoutFunc("sin"), WithoutConst("PI"))
+
+	for _, tt := range []struct {
+		name    string
+		parser  *parser
+		args    string
+		want    string
+		wantErr bool
+	}{
+		{"test#1", library, "sqr(x) + sin(PI/2)", "101", false},
+		{"test#2", library, "k", "", true},
+		{"test#3", user, "sqr(x) + sin(PI/2) + k", "0", false},
+		{"test#4", evaluation, "sqr(x) + k", "0", false},
+		{"test#5", evaluation, "PI", "", true},
+		{"test#6", evaluation.With(WithConst("k", 5)), "k", "5", false},
+		{"test#7", evaluation, "diff(x^2, x, 3) + x", "8", false},
+		{"test#8", user, "diff(k*x^2, x, 1)", "6", false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parser.Parse(context.TODO(), tt.args)
+			switch {
+			case tt.wantErr && err == nil:
+				t.Errorf("Result of %q: %v, want error", tt.args, got)
+
+			case !tt.wantErr && err != nil:
+				t.Errorf("Error parsing expression %q: %v", tt.args, err)
+
+			case !tt.wantErr && got.Text('g', 10) != tt.want:
+				t.Errorf("Result of %q: %s, want %s", tt.args, got.Text('g', 10), tt.want)
+
+			}
+		})
+	}
+
+	if _, ok := evaluation.LookupFunc("sin"); ok {
+		t.Errorf("Function sin not hidden by the evaluation layer")
+	}
+
+	var functions, constants []string
+	for _, f := range evaluation.ListFunctions() {
+		functions = append(functions, f.Name)
+	}
+	for _, c := range evaluation.ListConstants() {
+		constants = append(constants, fmt.Sprintf("%s=%s", c.Name, c.Value.Text('g', 10)))
+	}
+
+	if got, want := strings.Join(functions, ", "), "diff, len, sqr"; got != want {
+		t.Errorf("ListFunctions: %s, want %s", got, want)
+	}
+
+	if got, want := strings.Join(constants, ", "), "k=4"; got != want {
+		t.Errorf("ListConstants: %s, want %s", got, want)
+	}
+}
+
+func TestParseScopesConcurrently(t *testing.T) {
+	library := NewParser(WithConst("PI", math.Pi), WithFunc("sin", math.Sin))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			session := library.Scope(WithConst("n", i))
+			got, err := session.Scope(WithVar("x", func() int { return 1 })).Parse(context.TODO(), "sin(PI/2)*n + x")
+			switch {
+			case err != nil:
+				errs <- err
+
+			case got.Text('g', 10) != fmt.Sprint(i+1):
+				errs <- fmt.Errorf("result %s, want %d", got.Text('g', 10), i+1)
+
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("Error parsing concurrently: %v", err)
+	}
+}
@@ -0,0 +1,357 @@
+package parser
+
+import (
+	"math/big"
+)
+
+// Simplify returns a new, simplified parse tree of the expression given by root.
+// It folds constant subtrees as long as the result is exact, computing with rational numbers, e.g., 0.1+0.2 = 0.3, removes identities like x*1, x+0 and x^1,
+// combines like terms and factors, e.g., 2*x + x = 3*x and x*x = x^2, and encodes the unary minus
+// as a "-" node without right operand instead of 0 - x.
+// Functions, variables and constants are not evaluated, since the tree is simplified independently of a parser.
+// Hence, they might hold lists or fail to evaluate, so that they are never dropped, e.g., 0*x, x - x and x^0 are kept,
+// and the simplified tree evaluates to the same value or error as the original one.
+func Simplify(root Node) Node {
+	n, ok := root.(*node)
+	if !ok || n == nil {
+		return root
+	}
+
+	return simplifier{}.simplify(n)
+}
+
+// simplifier simplifies parse trees, see Simplify.
+type simplifier struct {
+	// defined assumes that the variables hold numbers at which the expression is defined,
+	// which allows to drop and cancel subtrees, e.g., 0*x = 0, x - x = 0, x/x = 1 and x^0 = 1.
+	defined bool
+}
+
+// simplify returns the simplified copy of n.
+func (s simplifier) simplify(n *node) *node {
+	if n == nil || n.IsLeaf() {
+		return clone(n)
+	}
+
+	switch n.value {
+	case "+", "-":
+		if n.value == "-" && n.right == nil {
+			return negateNode(s.simplify(n.left))
+		}
+
+		return s.combineTerms(n)
+
+	case "*":
+		return s.combineFactors(n)
+
+	case "/":
+		numerator, denominator := s.simplify(n.left), s.simplify(n.right)
+		switch x, y := numeric(numerator), numeric(denominator); {
+		case s.defined && equal(numerator, denominator) && y == nil:
+			return numberNode(1)
+
+		case x != nil && x.Sign() == 0 && (y == nil && !s.defined || y != nil && y.Sign() == 0): // 0/0 or 0/x with x = 0
+			return NewNode("/").SetLeft(numerator).SetRight(denominator)
+
+		case y != nil && y.Cmp(big.NewRat(-1, 1)) == 0:
+			return negateNode(numerator)
+
+		case numerator.value == "-" && numerator.right == nil: // -a/b = -(a/b)
+			return negateNode(quotientNode(numerator.left, denominator))
+
+		}
+
+		return quotientNode(numerator, denominator)
+
+	case "^":
+		base, exponent := s.simplify(n.left), s.simplify(n.right)
+		x, y := numeric(base), numeric(exponent)
+		switch {
+		case x != nil && (y != nil || s.defined) && x.Cmp(big.NewRat(1, 1)) == 0:
+			return numberNode(1)
+
+		case x != nil && y != nil && x.IsInt() && y.IsInt() && y.Sign() >= 0 && y.Cmp(big.NewRat(1024, 1)) <= 0 &&
+			(x.Sign() != 0 || y.Sign() != 0): // 0^0 is undefined
+			return numberNode(new(big.Rat).SetInt(new(big.Int).Exp(x.Num(), y.Num(), nil)))
+
+		}
+
+		return s.raisedNode(base, exponent)
+
+	case "√":
+		operand := s.simplify(n.left)
+		if x := numeric(operand); x != nil && x.Sign() >= 0 {
+			num, denom := new(big.Int).Sqrt(x.Num()), new(big.Int).Sqrt(x.Denom())
+			if root := new(big.Rat).SetFrac(num, denom); new(big.Rat).Mul(root, root).Cmp(x) == 0 {
+				return numberNode(root)
+			}
+		}
+
+		return NewNode("√").SetLeft(operand)
+
+	case "!":
+		operand := s.simplify(n.left)
+		if x := numeric(operand); x != nil && x.IsInt() && x.Sign() >= 0 && x.Cmp(big.NewRat(100, 1)) <= 0 {
+			return numberNode(new(big.Rat).SetInt(new(big.Int).MulRange(1, x.Num().Int64())))
+		}
+
+		return NewNode("!").SetLeft(operand)
+
+	}
+
+	// function calls, lists and the remaining operators keep their structure
+	return &node{value: n.value, left: s.simplify(n.left), right: s.simplify(n.right)}
+}
+
+// term is a summand given by its coefficient and its non-numeric factor, which is nil for constants.
+type term struct {
+	coefficient *big.Rat
+	factor      *node
+}
+
+// collectTerms appends the summands of n multiplied by sign to terms.
+func (s simplifier) collectTerms(terms []term, n *node, sign int) []term {
+	switch {
+	case n.value == "+":
+		return s.collectTerms(s.collectTerms(terms, n.left, sign), n.right, sign)
+
+	case n.value == "-" && n.right == nil:
+		return s.collectTerms(terms, n.left, -sign)
+
+	case n.value == "-":
+		return s.collectTerms(s.collectTerms(terms, n.left, sign), n.right, -sign)
+
+	}
+
+	simplified := s.simplify(n)
+	if simplified.value == "+" || simplified.value == "-" {
+		return s.collectTerms(terms, simplified, sign)
+	}
+
+	coefficient, factor := coefficientOf(simplified)
+	if sign < 0 {
+		coefficient.Neg(coefficient)
+	}
+
+	return append(terms, term{coefficient: coefficient, factor: factor})
+}
+
+// combineTerms simplifies the sum or difference n by adding the coefficients of like terms.
+// The constant term is moved to the end.
+func (s simplifier) combineTerms(n *node) *node {
+	var terms []term
+	constant := new(big.Rat)
+
+outer:
+	for _, t := range s.collectTerms(nil, n, 1) {
+		if t.factor == nil {
+			constant.Add(constant, t.coefficient)
+			continue
+		}
+
+		for i := range terms {
+			if equal(terms[i].factor, t.factor) {
+				terms[i].coefficient.Add(terms[i].coefficient, t.coefficient)
+				continue outer
+			}
+		}
+
+		terms = append(terms, t)
+	}
+
+	var result *node
+	for _, t := range append(terms, term{coefficient: constant}) {
+		if t.coefficient.Sign() == 0 {
+			if t.factor == nil || s.defined {
+				continue
+			}
+
+			t.coefficient.Abs(t.coefficient) // the factor is kept, e.g., x - x = 0*x
+		}
+
+		part := scaledNode(new(big.Rat).Abs(t.coefficient), t.factor)
+		switch {
+		case result == nil && t.coefficient.Cmp(big.NewRat(-1, 1)) == 0:
+			result = negateNode(part)
+
+		case result == nil: // a leading negative coefficient is kept, e.g., -2*x
+			result = scaledNode(t.coefficient, t.factor)
+
+		case t.coefficient.Sign() < 0:
+			result = NewNode("-").SetLeft(result).SetRight(part)
+
+		default:
+			result = NewNode("+").SetLeft(result).SetRight(part)
+
+		}
+	}
+
+	if result == nil {
+		return numberNode(0)
+	}
+
+	return result
+}
+
+// factor is a base raised to an exponent.
+type factor struct {
+	base     *node
+	exponent *node
+}
+
+// collectFactors appends the factors of n to factors and multiplies the numeric factors into coefficient.
+func (s simplifier) collectFactors(factors []factor, coefficient *big.Rat, n *node) []factor {
+	if n.value == "*" {
+		return s.collectFactors(s.collectFactors(factors, coefficient, n.left), coefficient, n.right)
+	}
+
+	simplified := s.simplify(n)
+	switch {
+	case numeric(simplified) != nil:
+		coefficient.Mul(coefficient, numeric(simplified))
+		return factors
+
+	case simplified.value == "*":
+		return s.collectFactors(factors, coefficient, simplified)
+
+	case simplified.value == "-" && simplified.right == nil:
+		coefficient.Neg(coefficient)
+		return s.collectFactors(factors, coefficient, simplified.left)
+
+	case simplified.value == "^":
+		return append(factors, factor{base: simplified.left, exponent: simplified.right})
+
+	}
+
+	return append(factors, factor{base: simplified, exponent: numberNode(1)})
+}
+
+// combineFactors simplifies the product n by adding the exponents of equal bases.
+// Unless the expression is defined, the exponents are only added if both are positive or negative integers,
+// since x^(1/2)*x^(1/2) = x does not hold for negative x and x^(0-1)*x^2 = x does not hold for zero.
+// The numeric coefficient is moved to the front, a coefficient of -1 is expressed by a unary minus.
+func (s simplifier) combineFactors(n *node) *node {
+	var factors []factor
+	coefficient := big.NewRat(1, 1)
+
+outer:
+	for _, f := range s.collectFactors(nil, coefficient, n) {
+		for i := range factors {
+			if equal(factors[i].base, f.base) && s.combinable(factors[i].exponent, f.exponent) {
+				factors[i].exponent = s.simplify(NewNode("+").SetLeft(factors[i].exponent).SetRight(f.exponent))
+				continue outer
+			}
+		}
+
+		factors = append(factors, f)
+	}
+
+	if coefficient.Sign() == 0 {
+		if len(factors) == 0 || s.defined {
+			return numberNode(0)
+		}
+
+		coefficient.Abs(coefficient) // the factors are kept, e.g., 0*x
+	}
+
+	var result *node
+	for _, f := range factors {
+		part := s.raisedNode(f.base, f.exponent)
+		switch {
+		case numeric(part) != nil: // e.g., x^0
+			coefficient.Mul(coefficient, numeric(part))
+
+		case result == nil:
+			result = part
+
+		default:
+			result = NewNode("*").SetLeft(result).SetRight(part)
+
+		}
+	}
+
+	if coefficient.Cmp(big.NewRat(-1, 1)) == 0 && result != nil {
+		return negateNode(result)
+	}
+
+	return scaledNode(coefficient, result)
+}
+
+// combinable reports whether the exponents a and b are integers of the same sign or whether the expression is defined.
+func (s simplifier) combinable(a, b *node) bool {
+	if s.defined {
+		return true
+	}
+
+	x, y := numeric(a), numeric(b)
+	return x != nil && y != nil && x.IsInt() && y.IsInt() && x.Sign() != 0 && x.Sign() == y.Sign()
+}
+
+// coefficientOf splits n into its numeric coefficient and the remaining factor, which is nil for numbers,
+// e.g., 2*x*y into 2 and x*y, or -x into -1 and x.
+func coefficientOf(n *node) (*big.Rat, *node) {
+	if x := numeric(n); x != nil {
+		return x, nil
+	}
+
+	switch {
+	case n.value == "-" && n.right == nil:
+		coefficient, rest := coefficientOf(n.left)
+		return coefficient.Neg(coefficient), rest
+
+	case n.value == "*":
+		coefficient, rest := coefficientOf(n.left)
+		if rest == nil {
+			return coefficient, n.right
+		}
+
+		return coefficient, NewNode("*").SetLeft(rest).SetRight(n.right)
+
+	}
+
+	return big.NewRat(1, 1), n
+}
+
+// negateNode creates the node -a, folding numbers and removing double negations.
+func negateNode(a *node) *node {
+	if x := numeric(a); x != nil {
+		return numberNode(new(big.Rat).Neg(x))
+	}
+
+	if a.value == "-" && a.right == nil {
+		return a.left
+	}
+
+	return NewNode("-").SetLeft(a)
+}
+
+// raisedNode creates the node a ^ b like powerNode, but keeps the exponent 0 unless a is a nonzero number
+// or the expression is defined, since 0^0 is undefined and a might be zero or a list.
+func (s simplifier) raisedNode(a, b *node) *node {
+	if y := numeric(b); y != nil && y.Sign() == 0 && !s.defined {
+		if x := numeric(a); x == nil || x.Sign() == 0 {
+			return NewNode("^").SetLeft(a).SetRight(b)
+		}
+	}
+
+	return powerNode(a, b)
+}
+
+// scaledNode creates the node coefficient * factor, omitting a coefficient of one.
+// The coefficient is multiplied with the leftmost factor of a product, e.g., 2*x*y rather than 2*(x*y).
+// If factor is nil, it creates the number node of the coefficient.
+func scaledNode(coefficient *big.Rat, factor *node) *node {
+	switch {
+	case factor == nil:
+		return numberNode(coefficient)
+
+	case coefficient.Cmp(big.NewRat(1, 1)) == 0:
+		return factor
+
+	case factor.value == "*":
+		return NewNode("*").SetLeft(scaledNode(coefficient, factor.left)).SetRight(factor.right)
+
+	}
+
+	return NewNode("*").SetLeft(numberNode(coefficient)).SetRight(factor)
+}
@@ -0,0 +1,163 @@
+package parser
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+)
+
+// canonical converts the unary minus encoded as 0 - x in the parse tree n
+// into the encoding used by Simplify, i.e., a "-" node without right operand or a negative number.
+func canonical(n *node) *node {
+	if n == nil {
+		return nil
+	}
+
+	if n.value == "-" && n.left.IsLeaf() && n.left.value == "0" {
+		return negateNode(canonical(n.right))
+	}
+
+	return &node{value: n.value, left: canonical(n.left), right: canonical(n.right)}
+}
+
+// tree parses the expression and returns the root node of its parse tree.
+func tree(t *testing.T, expr string) *node {
+	t.Helper()
+
+	tokens, err := Tokenize(expr)
+	if err != nil {
+		t.Fatalf("Error tokenizing expression %q: %v", expr, err)
+	}
+
+	root, err := tokens.Tree()
+	if err != nil {
+		t.Fatalf("Error parsing expression %q: %v", expr, err)
+	}
+
+	return root.(*node)
+}
+
+func TestSimplify(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		args string
+		want string
+	}{
+		{"test#1", "1 + 2*3", "7"},
+		{"test#2", "x*1 + 0", "x"},
+		{"test#3", "x^1 - 0*y", "x + 0*y"},
+		{"test#4", "2*x + x", "3*x"},
+		{"test#5", "x + 1 + x - 3", "2*x - 2"},
+		{"test#6", "-x", "0 - x"},
+		{"test#7", "-(-x)", "x"},
+		{"test#8", "-3 + x", "x - 3"},
+		{"test#9", "x*x*2*y*3", "6*x^2*y"},
+		{"test#10", "x^2*x^3/x^5", "x^5/x^5"},
+		{"test#11", "y - (x - y)", "2*y - x"},
+		{"test#12", "2*(x + 1)*(x + 1)", "2*(x + 1)^2"},
+		{"test#13", "x*(0 - 2)", "-2*x"},
+		{"test#14", "sin(1*x + 0)", "sin(x)"},
+		{"test#15", "2^10 + √16 + 3!", "1034"},
+		{"test#16", "1/3 + √2", "1/3 + √2"},
+		{"test#17", "x/1 + x^0 + 1^x", "x + x^0 + 1^x"},
+		{"test#18", "(0 - x)/y", "0 - x/y"},
+		{"test#19", "[1 + 1, x*1][2]", "[2, x][2]"},
+		{"test#20", "0^0 + 2^0", "0^0 + 1"},
+		{"test#21", "1^(1/0) + 1^2", "1^(1/0) + 1"},
+		{"test#22", "(0 - 1)!*0", "0*(0 - 1)!"},
+		{"test#23", "√(x - 1)*0", "0*√(x - 1)"},
+		{"test#24", "[1, 2]*0", "0*[1, 2]"},
+		{"test#25", "[1, 2] - [1, 2]", "0*[1, 2]"},
+		{"test#26", "1^[1, 2]", "1^[1, 2]"},
+		{"test#27", "x^(1/2)*x^(1/2) + x^(0 - 1)*x^2", "x^(1/2)*x^(1/2) + x^(0 - 1)*x^2"},
+		{"test#28", "0/x + 0/0", "0/x + 0/0"},
+		{"test#29", "0.1 + 0.2", "0.3"},
+		{"test#30", "100000000000000000000/3", "100000000000000000000/3"},
+		{"test#31", "100000000000000000001*3 - 0.5*x*0.2", "-0.1*x + 300000000000000000003"},
+		{"test#32", "√0.01 + 0.1*0.1 + 1.5^2", "1.5^2 + 0.11"},
+		{"test#33", "20!/3 + 2^70/3", "1180591620717411303424/3 + 810967336058880000"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Simplify(tree(t, tt.args)); !equal(got.(*node), canonical(tree(t, tt.want))) {
+				t.Errorf("Simplify(%q) does not match %q", tt.args, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimplifyPreservesValue(t *testing.T) {
+	p := NewParser(
+		WithFunc("sin", math.Sin),
+		WithVar("x", func() float64 { return 1.5 }),
+		WithVar("y", func() float64 { return -2 }),
+	)
+
+	for _, expr := range []string{
+		"x*x*2*y*3 - (x - y)^2",
+		"-(x - 2*y)/(y + x*1) + x^0",
+		"2^(x*0 + 3) - sin(x)*sin(x)/sin(x)",
+		"√(4*x*x) - 2*x + 5!",
+	} {
+		want, err := p.Parse(context.TODO(), expr)
+		if err != nil {
+			t.Fatalf("Error parsing expression %q: %v", expr, err)
+		}
+
+		got, err := Simplify(tree(t, expr)).Evaluate(context.TODO(), p)
+		if err != nil {
+			t.Fatalf("Error evaluating simplified expression %q: %v", expr, err)
+		}
+
+		if got.Text('g', 12) != want.Text('g', 12) {
+			t.Errorf("Simplified %q evaluates to %s, want %s", expr, got.Text('g', 12), want.Text('g', 12))
+		}
+	}
+}
+
+func TestSimplifyPreservesErrorsAndLists(t *testing.T) {
+	p := NewParser(
+		WithVar("x", func() float64 { return 0 }),
+	)
+
+	for _, tt := range []struct {
+		name    string
+		args    string
+		want    string
+		wantErr bool
+	}{
+		{"test#1", "0^0", "", true},
+		{"test#2", "x^0", "", true},
+		{"test#3", "x^2*x^(0 - 2)", "", true},
+		{"test#4", "1^(1/0)", "", true},
+		{"test#5", "(0 - 1)!*0", "", true},
+		{"test#6", "√(x - 1)*0", "", true},
+		{"test#7", "x/x", "", true},
+		{"test#8", "0/x", "", true},
+		{"test#9", "[1, 2]*0", "[0, 0]", false},
+		{"test#10", "[1, 2] - [1, 2]", "[0, 0]", false},
+		{"test#11", "1^[1, 2]", "[1, 1]", false},
+		{"test#12", "x + [1, 2]^0*0", "[0, 0]", false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := tree(t, tt.args).Evaluate(context.TODO(), p)
+			if (err != nil) != tt.wantErr || err == nil && Text(want, 'g', -1) != tt.want {
+				t.Fatalf("Evaluate(%q) = %v, %v, want %q, error: %t", tt.args, want, err, tt.want, tt.wantErr)
+			}
+
+			got, err := Simplify(tree(t, tt.args)).Evaluate(context.TODO(), p)
+			if (err != nil) != tt.wantErr || err == nil && Text(got, 'g', -1) != tt.want {
+				t.Errorf("Simplify(%q).Evaluate() = %v, %v, want %q, error: %t", tt.args, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSimplifyDeepTree(t *testing.T) {
+	depth := 100000
+	root := tree(t, strings.Repeat("1+(", depth)+"x"+strings.Repeat(")", depth))
+
+	if got := Simplify(root); !equal(got.(*node), tree(t, "x + 100000")) {
+		t.Errorf("Simplified tree of depth %d: %v, want x + 100000", depth, got)
+	}
+}
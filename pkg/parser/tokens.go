@@ -2,9 +2,8 @@ package parser
 
 import (
 	"fmt"
+	"slices"
 	"strings"
-
-	"github.com/sarumaj/edu-taschenrechner/pkg/runes"
 )
 
 // Tokens is an interface for tokenizing an expression and parsing it into a parse tree
@@ -21,197 +20,326 @@ func (tokens *tokens) append(token string) {
 	*tokens = append(*tokens, token)
 }
 
-// consume consumes the next token from the token list
-// and returns it. If the token list is empty, it returns an empty string.
-func (tokens *tokens) consume() string {
-	if len(*tokens) > 0 {
-		token := (*tokens)[0]
-		*tokens = (*tokens)[1:]
-		return token
-	}
-
-	return ""
-}
-
 // len returns the number of tokens in the list.
 func (tokens *tokens) len() int {
 	return len(*tokens)
 }
 
-// parseExpr parses an expression and returns the root node of the parse tree
-func (tokens *tokens) parseExpr() (Node, error) {
-	// parse addition and subtraction first
-	node, err := tokens.parseAddSub()
-	if err != nil {
-		return nil, err
+// builder builds the parse tree of a list of tokens iteratively, i.e., without recursion,
+// hence arbitrarily deeply nested expressions do not exhaust the call stack.
+// The grammar is parsed by recursive descent, whose pending rules are kept on an explicit stack:
+//
+//	expression = term { ("+" | "-" | "±") term }
+//	term       = factor { ("*" | "/" | "@") factor }
+//	factor     = ( "(" expression ")" | "-" factor | "√" factor | "[" list "]" | name "(" list ")" | token )
+//	             { "[" expression "]" } [ "^" factor ] { "!" | "°" }
+//	list       = { expression [","] }
+//
+// The depth of the tree is checked while building it, hence too deeply nested expressions are rejected early.
+type builder struct {
+	tokens   tokens
+	pos      int // position of the next token
+	stack    []pendingRule
+	items    []*node // elements of the pending lists and arguments of the pending function calls
+	maxDepth int     // maximum depth of the tree, disabled if zero or less
+	nesting  int     // number of pending rules creating a node above the tree parsed next
+}
+
+// pendingRule is a rule pending on the stack of a builder, which waits for the tree of an operand.
+type pendingRule struct {
+	rule     rule
+	node     *node // left operand of an operator, e.g., the base of an exponentiation
+	depth    int   // depth of the left operand or of the deepest element or argument parsed so far
+	operator int   // position of the token of the operator or of the list or function node
+	items    int   // position of the first element or argument of a list or function call in the items
+}
+
+// rule is a rule of the grammar, see builder.
+type rule int
+
+// rules of the grammar pending on the stack of a builder
+const (
+	expressionRule rule = iota // sum of terms
+	groupRule                  // sum of terms in parentheses
+	termRule                   // product of factors, pushed once a factor is followed by a multiplication
+	negationRule               // unary minus
+	rootRule                   // square root
+	indexRule                  // index of a factor
+	powerRule                  // exponent of a factor
+	listRule                   // elements of a list literal
+	callRule                   // arguments of a function call
+)
+
+// actions of a builder, see run
+const (
+	parseExpression = iota // parse an expression
+	parseFactor            // parse a factor
+	parsePostfix           // parse the indexes and the exponent following a factor
+	parseFactorials        // parse the factorial and degree operators following a factor
+	parseItem              // parse the next element or argument of a list or function call
+	reduce                 // pass the tree to the pending rule on top of the stack
+)
+
+// consume consumes the next token and returns it. If there are no tokens left, it returns an empty string.
+func (b *builder) consume() string {
+	token := b.peek()
+	if b.pos < len(b.tokens) {
+		b.pos++
 	}
 
-	return node, nil
+	return token
 }
 
-// parseAddSub parses addition and subtraction
-func (tokens *tokens) parseAddSub() (Node, error) {
-	// parse multiplication and division first
-	node, err := tokens.parseMulDiv()
-	if err != nil {
-		return nil, err
+// peek returns the next token without consuming it. If there are no tokens left, it returns an empty string.
+func (b *builder) peek() string {
+	if b.pos < len(b.tokens) {
+		return b.tokens[b.pos]
 	}
 
-	for tokens.len() > 0 {
-		if tokens.peek() != "+" && tokens.peek() != "-" {
-			break // Not an addition or subtraction operator
-		}
+	return ""
+}
 
-		// consume the operator
-		operator := tokens.consume()
+// peekAnyOf reports whether the next token is any of the given ones.
+func (b *builder) peekAnyOf(tokens ...string) bool {
+	return b.pos < len(b.tokens) && slices.Contains(tokens, b.tokens[b.pos])
+}
 
-		// parse the right side of the expression
-		right, err := tokens.parseMulDiv()
-		if err != nil {
-			return nil, err
-		}
+// push pushes a pending rule on the stack.
+func (b *builder) push(r rule, node *node, depth, operator int) {
+	b.stack = append(b.stack, pendingRule{rule: r, node: node, depth: depth, operator: operator, items: len(b.items)})
+	if r.nests() {
+		b.nesting++
+	}
+}
 
-		// create a new node with the operator and the left and right nodes
-		node = NewNode(operator).SetLeft(node).SetRight(right)
+// value returns the value of the node created by the pending rule.
+func (b *builder) value(pending pendingRule) string {
+	if pending.rule == indexRule {
+		return "[]"
 	}
 
-	return node, nil
+	return b.tokens[pending.operator]
 }
 
-// parseMulDiv parses multiplication and division
-func (tokens *tokens) parseMulDiv() (Node, error) {
-	// parse factors first
-	node, err := tokens.parseFactor()
-	if err != nil {
-		return nil, err
+// pop pops the pending rule on top of the stack.
+func (b *builder) pop() pendingRule {
+	top := b.stack[len(b.stack)-1]
+	b.stack = b.stack[:len(b.stack)-1]
+	if top.rule.nests() {
+		b.nesting--
 	}
 
-	for tokens.len() > 0 {
-		if tokens.peek() != "*" && tokens.peek() != "/" {
-			break // Not a multiplication or division operator
-		}
+	return top
+}
 
-		// consume the operator
-		operator := tokens.consume()
+// nests reports whether the rule creates a node above the trees of its operands,
+// which is not the case for sums of terms until the first addition, subtraction or tolerance.
+func (r rule) nests() bool {
+	return r != expressionRule && r != groupRule
+}
 
-		// parse the right side of the expression
-		right, err := tokens.parseFactor()
-		if err != nil {
-			return nil, err
+// build parses an expression and returns the root node of its parse tree.
+// The tokens following the expression, if any, are not consumed, see pos.
+// Errors within lists and function calls are annotated with the enclosing lists and function calls.
+func (b *builder) build() (Node, error) {
+	tree, err := b.run()
+	if err != nil {
+		for i := len(b.stack) - 1; i >= 0; i-- {
+			switch b.stack[i].rule {
+			case listRule:
+				err = fmt.Errorf("%w in list", err)
+			case callRule:
+				err = fmt.Errorf("%w in function call", err)
+			}
 		}
 
-		// create a new node with the operator and the left and right nodes
-		node = NewNode(operator).SetLeft(node).SetRight(right)
+		return nil, err
 	}
 
-	return node, nil
+	return tree, nil
 }
 
-// parseFactor parses a factor (number, variable, function call, or sub-expression)
-func (tokens *tokens) parseFactor() (Node, error) {
-	if tokens.len() == 0 {
-		return nil, fmt.Errorf("unexpected end of expression")
-	}
-
-	var node Node // the node to return
-
-	// consume the token
-	switch token := tokens.consume(); {
-	case token == "(": // Handle sub-expression
-		subExprNode, err := tokens.parseExpr()
-		if err != nil {
+// run runs the actions of the builder until the expression is parsed.
+func (b *builder) run() (*node, error) {
+	var tree *node // tree of the rule parsed last
+	var depth int  // depth of the tree
+	action := parseExpression
+	for {
+		// each pending rule creating a node adds a level to the tree
+		if err := exceeded(DepthLimit, b.maxDepth, max(depth, b.nesting)); err != nil {
 			return nil, err
 		}
 
-		if tokens.len() == 0 || tokens.peek() != ")" {
-			return nil, fmt.Errorf("missing closing parenthesis")
-		}
+		switch action {
+		case parseExpression:
+			b.push(expressionRule, nil, 0, -1)
+			action = parseFactor
 
-		_ = tokens.consume() // consume the ')'
-		node = subExprNode
+		case parseFactor:
+			if b.pos == len(b.tokens) {
+				return nil, fmt.Errorf("unexpected end of expression")
+			}
 
-	case token == "-": // Handle unary minus
-		subNode, err := tokens.parseFactor()
-		if err != nil {
-			return nil, err
-		}
+			switch token := b.consume(); {
+			case token == "(": // Handle sub-expression
+				b.push(groupRule, nil, 0, -1)
 
-		node = NewNode("-").SetLeft(NewNode("0")).SetRight(subNode)
+			case token == "-": // Handle unary minus
+				b.push(negationRule, nil, 0, -1)
 
-	case token == "√": // Handle square root
-		subNode, err := tokens.parseFactor() // Parse the operand
-		if err != nil {
-			return nil, err
-		}
+			case token == "√": // Handle square root
+				b.push(rootRule, nil, 0, -1)
+
+			case token == "[": // Handle list literal
+				b.push(listRule, nil, 0, b.pos-1)
+				action = parseItem
 
-		node = NewNode("√").SetLeft(subNode)
+			case b.peek() == "(": // Handle function call, token is the function name
+				b.push(callRule, nil, 0, b.pos-1)
+				_ = b.consume() // consume the '('
+				action = parseItem
 
-	case tokens.len() > 0 && tokens.peek() == "(": // Handle function call
-		_ = tokens.consume() // consume the '('
+			default: // Handle any other token
+				tree, depth, action = &node{value: token}, 1, parsePostfix
 
-		var args []Node // arguments to the function
-		for tokens.len() > 0 && tokens.peek() != ")" {
-			arg, err := tokens.parseExpr()
-			if err != nil {
-				return nil, err
 			}
 
-			args = append(args, arg)
+		case parsePostfix:
+			switch b.peek() {
+			case "[": // Handle indexing operator
+				_ = b.consume()
+				b.push(indexRule, tree, depth, b.pos-1)
+				action = parseExpression
+
+			case "^": // Handle exponentiation operator
+				b.push(powerRule, tree, depth, b.pos)
+				_ = b.consume()
+				action = parseFactor
+
+			default:
+				action = parseFactorials
 
-			// consume the ',' if there are more arguments
-			if tokens.len() > 0 && tokens.peek() == "," {
-				_ = tokens.consume()
 			}
-		}
 
-		if tokens.len() > 0 && tokens.peek() != ")" {
-			return nil, fmt.Errorf("missing closing parenthesis in function call")
-		}
+		case parseFactorials:
+			for b.peekAnyOf("!", "°") {
+				tree, depth = &node{value: b.consume(), left: tree}, depth+1
+			}
+			action = reduce
 
-		_ = tokens.consume() // consume the ')'
+		case parseItem:
+			top := &b.stack[len(b.stack)-1]
+			closing, kind := "]", "bracket"
+			if top.rule == callRule {
+				closing, kind = ")", "parenthesis"
+			}
 
-		// Create a function node where the arguments are linked as a list
-		var argsNode Node
-		for i := len(args) - 1; i >= 0; i-- { // Link the arguments as a list, right to left
-			argsNode = NewNode("").SetLeft(args[i]).SetRight(argsNode)
-		}
+			switch {
+			case b.pos == len(b.tokens):
+				return nil, fmt.Errorf("missing closing %s", kind)
 
-		// token is the function name
-		// arguments are linked as a list in the left child of the function node
-		node = NewNode(token).SetLeft(argsNode)
+			case b.peek() != closing:
+				action = parseExpression
 
-	default: // Handle any other token
-		node = NewNode(token)
-	}
+			default:
+				_ = b.consume() // consume the closing token
 
-	// Check for exponentiation operator
-	if tokens.len() > 0 && tokens.peek() == "^" {
-		token := tokens.consume()              // consume the '^'
-		rightNode, err := tokens.parseFactor() // Parse the exponent
-		if err != nil {
-			return nil, err
-		}
+				// elements are linked as a list in the left child of the list or function node,
+				// i.e., each node holds an element in its left child and the next node in its right child
+				var list *node
+				for i := len(b.items) - 1; i >= top.items; i-- {
+					list = &node{left: b.items[i], right: list}
+				}
 
-		node = NewNode(token).SetLeft(node).SetRight(rightNode)
-	}
+				clear(b.items[top.items:])
+				b.items = b.items[:top.items]
+				pending := b.pop()
+				tree, depth, action = &node{value: b.value(pending), left: list}, pending.depth+1, parsePostfix
 
-	// Check if the next token is a factorial operator or a degree operator
-	for tokens.len() > 0 && (tokens.peek() == "!" || tokens.peek() == "°") {
-		token := tokens.consume() // consume the "!" or "°"
-		node = NewNode(token).SetLeft(node)
-	}
+			}
 
-	return node, nil
-}
+		case reduce:
+			if len(b.stack) == 0 {
+				return tree, nil
+			}
 
-// peek returns the next token in the list without consuming it.
-// If the list is empty, it returns an empty string.
-func (tokens *tokens) peek() string {
-	if len(*tokens) > 0 {
-		return (*tokens)[0]
-	}
+			top := &b.stack[len(b.stack)-1]
+			switch top.rule {
+			case termRule:
+				// create a new node with the operator and the left and right nodes
+				tree, depth = &node{value: b.value(*top), left: top.node, right: tree}, 1+max(top.depth, depth)
+				if b.peekAnyOf("*", "/", "@") {
+					top.node, top.depth, top.operator = tree, depth, b.pos
+					_ = b.consume()
+					action = parseFactor
+				} else {
+					b.pop()
+				}
+
+			case expressionRule, groupRule:
+				if b.peekAnyOf("*", "/", "@") { // the tree is the first factor of a term
+					b.push(termRule, tree, depth, b.pos)
+					_ = b.consume()
+					action = parseFactor
+					break
+				}
+
+				if top.node != nil { // create a new node with the operator and the left and right nodes
+					tree, depth = &node{value: b.value(*top), left: top.node, right: tree}, 1+max(top.depth, depth)
+				}
+
+				if b.peekAnyOf("+", "-", "±") {
+					top.node, top.depth, top.operator = tree, depth, b.pos
+					_ = b.consume()
+					action = parseFactor
+					break
+				}
+
+				if top.rule == groupRule {
+					if b.peek() != ")" {
+						return nil, fmt.Errorf("missing closing parenthesis")
+					}
+
+					_ = b.consume() // consume the ')'
+					action = parsePostfix
+				}
+				b.pop()
+
+			case negationRule:
+				b.pop()
+				tree, depth, action = &node{value: "-", left: &node{value: "0"}, right: tree}, depth+1, parsePostfix
+
+			case rootRule:
+				b.pop()
+				tree, depth, action = &node{value: "√", left: tree}, depth+1, parsePostfix
+
+			case indexRule:
+				if b.peek() != "]" {
+					return nil, fmt.Errorf("missing closing bracket in index")
+				}
+
+				_ = b.consume() // consume the ']'
+				pending := b.pop()
+				tree, depth, action = &node{value: b.value(pending), left: pending.node, right: tree}, 1+max(pending.depth, depth), parsePostfix
+
+			case powerRule:
+				pending := b.pop()
+				tree, depth, action = &node{value: b.value(pending), left: pending.node, right: tree}, 1+max(pending.depth, depth), parseFactorials
+
+			case listRule, callRule:
+				b.items = append(b.items, tree)
+				top.depth = max(top.depth, depth)
+
+				// consume the ',' if there are more items
+				if b.peek() == "," {
+					_ = b.consume()
+				}
+				action = parseItem
 
-	return ""
+			}
+
+		}
+	}
 }
 
 // Compare compares the tokens with the given strings
@@ -231,51 +359,16 @@ func (tokens tokens) Compare(others ...string) bool {
 
 // Tree parses the expression and returns the root node of the parse tree
 func (tokens *tokens) Tree() (Node, error) {
-	return tokens.parseExpr()
+	return (&builder{tokens: *tokens}).build()
 }
 
-// Tokenize splits the expression into tokens
+// Tokenize splits the expression into tokens, see Scanner for tokens along with their kinds and positions.
 func Tokenize(expr string) (Tokens, error) {
 	var tokens tokens
-	var token strings.Builder
-
-	for i := 0; i < len([]rune(expr)); i++ {
-		switch ch := []rune(expr)[i]; {
-		case ch == ' ': // Skip whitespace
-
-		case runes.IsDigit(ch), ch == '.': // Handle numbers (including floating point)
-			token.WriteRune(ch)
-
-		case // Handle letters (for variable names and function names or units)
-			runes.InRange(ch, 'a', 'z'), runes.InRange(ch, 'A', 'Z'), ch == '_', i > 0 && runes.IsDigit(ch):
-
-			// If we have a number accumulated, append it as a token first
-			if token.Len() > 0 && (runes.IsDigit(rune(token.String()[token.Len()-1])) || token.String() == ".") {
-				tokens.append(token.String())
-				token.Reset()
-			}
-
-			// Accumulate letters into the current token
-			token.WriteRune(ch)
-
-		case runes.IsAnyOf(ch, "(),+-*/!√^°"): // Handle operators, parentheses, and the degree symbol
-			if token.Len() > 0 {
-				tokens.append(token.String())
-				token.Reset()
-			}
-			tokens.append(string(ch))
-
-		default:
-			if token.Len() > 0 {
-				tokens.append(token.String())
-				token.Reset()
-			}
-
-		}
-	}
-	if token.Len() > 0 {
-		tokens.append(token.String())
+	scanner := NewScanner(strings.NewReader(expr))
+	for scanner.Scan() {
+		tokens.append(scanner.Token().Text)
 	}
 
-	return &tokens, nil
+	return &tokens, scanner.Err()
 }
@@ -26,6 +26,11 @@ func TestExampleFor_Tokenize(t *testing.T) {
 		{"test#16", "sin( 30° )! + 1", []string{"sin", "(", "30", "°", ")", "!", "+", "1"}},
 		{"test#17", "6 ^ - 2", []string{"6", "^", "-", "2"}},
 		{"test#18", "6!°", []string{"6", "!", "°"}},
+		{"test#19", "[1, x][2]", []string{"[", "1", ",", "x", "]", "[", "2", "]"}},
+		{"test#20", "A@[1, 2]", []string{"A", "@", "[", "1", ",", "2", "]"}},
+		{"test#21", "1.5e-3 + 2E5*e", []string{"1.5e-3", "+", "2E5", "*", "e"}},
+		{"test#22", "2e + xe1", []string{"2", "e", "+", "xe1"}},
+		{"test#23", "2×π\t÷ 3", []string{"2", "×", "π", "÷", "3"}},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 
@@ -0,0 +1,85 @@
+package parser
+
+import "strings"
+
+// Step is the reduction of a subexpression to its value during an evaluation, see WithObserver.
+type Step struct {
+	Node     Node    // reduced subexpression
+	Operator string  // operator, function name, or the name of the constant or variable
+	Operands []Value // values of the operands or arguments, none for constants, variables and diff
+	Result   Value   // value of the subexpression
+}
+
+// observe reports the reduction of the node to the observer of the parser, if there is any.
+func (p *parser) observe(n *node, operands []Value, result Value) {
+	if p.observer == nil {
+		return
+	}
+
+	p.observer(Step{Node: n, Operator: n.value, Operands: operands, Result: result})
+}
+
+// Rewrite returns the successive expressions of an evaluation of the parse tree given by root in the given notation.
+// Starting with the expression itself, the subexpressions reduced by the steps are replaced by their values
+// one after another, e.g., 1.3 + 12*(-7) + 1, 1.3 + (-84) + 1, -82.7 + 1 and -81.7.
+// Steps of other trees and steps which do not change the expression are skipped.
+func Rewrite(root Node, steps []Step, notation Notation) []string {
+	n, ok := root.(*node)
+	if !ok || n == nil {
+		return nil
+	}
+
+	copies := make(map[*node]*node)
+	tree := cloneMapped(n, copies)
+	expressions := []string{Format(tree, notation)}
+
+	for _, step := range steps {
+		original, _ := step.Node.(*node)
+		reduced, ok := copies[original]
+		if !ok || step.Result == nil {
+			continue
+		}
+
+		*reduced = *valueNode(step.Result)
+		if expression := Format(tree, notation); expression != expressions[len(expressions)-1] {
+			expressions = append(expressions, expression)
+		}
+	}
+
+	return expressions
+}
+
+// cloneMapped returns a deep copy of n and records the copy of each node in copies.
+func cloneMapped(n *node, copies map[*node]*node) *node {
+	if n == nil {
+		return nil
+	}
+
+	c := &node{value: n.value, left: cloneMapped(n.left, copies), right: cloneMapped(n.right, copies)}
+	copies[n] = c
+
+	return c
+}
+
+// valueNode creates the node of a value, i.e., a number or a list literal.
+// Numbers are rounded to 10 significant digits, intervals are given by the list literals of their bounds.
+func valueNode(value Value) *node {
+	if interval, ok := value.(Interval); ok {
+		return &node{value: "[", left: link([]*node{
+			{value: strings.TrimPrefix(bound(interval.Lo, 'g', 10, false), "+")},
+			{value: strings.TrimPrefix(bound(interval.Hi, 'g', 10, true), "+")},
+		})}
+	}
+
+	list, ok := value.(List)
+	if !ok {
+		return &node{value: strings.TrimPrefix(value.Text('g', 10), "+")} // +Inf
+	}
+
+	elements := make([]*node, len(list))
+	for i, element := range list {
+		elements[i] = valueNode(element)
+	}
+
+	return &node{value: "[", left: link(elements)}
+}
@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestExampleFor_ParserWithObserver(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		args  string
+		steps []string // operator: operands = result
+		want  []string
+	}{
+		{"test#1", "1.3+(12*-7)+1",
+			[]string{"-: 0, 7 = -7", "*: 12, -7 = -84", "+: 1.3, -84 = -82.7", "+: -82.7, 1 = -81.7"},
+			[]string{"1.3 + 12*(-7) + 1", "1.3 + (-84) + 1", "-82.7 + 1", "-81.7"}},
+		{"test#2", "2*PI",
+			[]string{"PI:  = 3.141592654", "*: 2, 3.141592654 = 6.283185307"},
+			[]string{"2*PI", "2*3.141592654", "6.283185307"}},
+		{"test#3", "sqr(1 + 2)! / x",
+			[]string{"+: 1, 2 = 3", "sqr: 3 = 9", "!: 9 = 362880", "x:  = 4", "/: 362880, 4 = 90720"},
+			[]string{"sqr(1 + 2)!/x", "sqr(3)!/x", "9!/x", "362880/x", "362880/4", "90720"}},
+		{"test#4", "[1, 2] * 3 + diff(x^2, x)",
+			[]string{"*: [1, 2], 3 = [3, 6]", "diff:  = 8", "+: [3, 6], 8 = [11, 14]"},
+			[]string{"[1, 2]*3 + diff(x^2, x)", "[3, 6] + diff(x^2, x)", "[3, 6] + 8", "[11, 14]"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var steps []Step
+			p := NewParser(
+				WithFunc("sqr", func(x float64) float64 { return x * x }),
+				WithConst("PI", math.Pi),
+				WithVar("x", func() float64 { return 4 }),
+				WithObserver(func(step Step) { steps = append(steps, step) }),
+			)
+
+			root, err := p.Tree(tt.args)
+			if err != nil {
+				t.Fatalf("Error parsing expression %q: %v", tt.args, err)
+			}
+
+			if _, err := root.Evaluate(context.TODO(), p); err != nil {
+				t.Fatalf("Error evaluating expression %q: %v", tt.args, err)
+			}
+
+			var got []string
+			for _, step := range steps {
+				operands := make([]string, len(step.Operands))
+				for i, operand := range step.Operands {
+					operands[i] = operand.Text('g', 10)
+				}
+				got = append(got, step.Operator+": "+strings.Join(operands, ", ")+" = "+step.Result.Text('g', 10))
+			}
+
+			if !slices.Equal(got, tt.steps) {
+				t.Errorf("Steps of %q: %q, want %q", tt.args, got, tt.steps)
+			}
+
+			if got := Rewrite(root, steps, Infix); !slices.Equal(got, tt.want) {
+				t.Errorf("Rewrite(%q): %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// make sure that scalars, lists and intervals implement the Value interface
+var (
+	_ Value = (*big.Float)(nil)
+	_ Value = List(nil)
+	_ Value = Interval{}
+)
+
+// Value is the result of an evaluation.
+// It is either a scalar (*big.Float), a List of values or an Interval, see WithIntervals.
+type Value interface {
+	Text(format byte, prec int) string
+}
+
+// List is a list of values, e.g., the vector [1, 2, 3].
+// Lists may be nested.
+type List []Value
+
+// Text formats the list like a list literal, e.g., [1, 2, 3],
+// using the given format and precision for the scalars.
+func (list List) Text(format byte, prec int) string {
+	texts := make([]string, len(list))
+	for i, value := range list {
+		texts[i] = value.Text(format, prec)
+	}
+
+	return "[" + strings.Join(texts, ", ") + "]"
+}
+
+// ErrNaN is the error of undefined results, which are not a number, e.g., Inf - Inf or 0*Inf.
+// Infinities are values like any other number, e.g., 1/Inf evaluates to 0.
+var ErrNaN = errors.New("not a number")
+
+// Text formats the value like its Text method, but prints infinities as ∞ and -∞, e.g., [1, ∞].
+func Text(value Value, format byte, prec int) string {
+	return strings.NewReplacer("+Inf", "∞", "-Inf", "-∞").Replace(value.Text(format, prec))
+}
+
+// Floats flattens the values into a list of scalars.
+// Nested lists are flattened recursively.
+func Floats(values ...Value) ([]*big.Float, error) {
+	var floats []*big.Float
+	for _, value := range values {
+		switch value := value.(type) {
+		case *big.Float:
+			if value == nil {
+				return nil, fmt.Errorf("missing value")
+			}
+
+			floats = append(floats, value)
+
+		case List:
+			nested, err := Floats(value...)
+			if err != nil {
+				return nil, err
+			}
+
+			floats = append(floats, nested...)
+
+		default:
+			return nil, fmt.Errorf("unsupported value: %T", value)
+
+		}
+	}
+
+	return floats, nil
+}
+
+// Matrix converts the value to a matrix, i.e., a list of rows of numbers of equal length, e.g., [[1, 2], [3, 4]].
+// A list of numbers is treated as a matrix with a single row.
+func Matrix(value Value) ([][]*big.Float, error) {
+	list, ok := value.(List)
+	if !ok || len(list) == 0 {
+		return nil, fmt.Errorf("matrix must be a non-empty list of rows")
+	}
+
+	if _, ok := list[0].(List); !ok {
+		row, err := vector(list)
+		if err != nil {
+			return nil, err
+		}
+
+		return [][]*big.Float{row}, nil
+	}
+
+	rows := make([][]*big.Float, len(list))
+	for i, element := range list {
+		var err error
+		if rows[i], err = vector(element); err != nil {
+			return nil, err
+		}
+
+		if len(rows[i]) != len(rows[0]) {
+			return nil, fmt.Errorf("matrix rows must have equal length, got %d and %d", len(rows[0]), len(rows[i]))
+		}
+	}
+
+	return rows, nil
+}
+
+// Scalar returns the value as a scalar.
+// It reports false if the value is a list.
+func Scalar(value Value) (*big.Float, bool) {
+	scalar, ok := value.(*big.Float)
+	return scalar, ok && scalar != nil
+}
+
+// scalar returns the element of a list as a scalar.
+// It fails if the element is missing or of another type, e.g., an Interval.
+func scalar(value Value) (*big.Float, error) {
+	switch value := value.(type) {
+	case *big.Float:
+		if value != nil {
+			return value, nil
+		}
+
+	case nil:
+
+	default:
+		return nil, fmt.Errorf("unsupported value: %T", value)
+
+	}
+
+	return nil, fmt.Errorf("missing value")
+}
+
+// apply applies fn to every scalar of value, retaining the structure of nested lists.
+func apply(value Value, fn func(*big.Float) (Value, error)) (Value, error) {
+	return each(value, func(element Value) (Value, error) {
+		x, err := scalar(element)
+		if err != nil {
+			return nil, err
+		}
+
+		return fn(x)
+	})
+}
+
+// broadcast applies fn element-wise to the scalars of left and right, see combine.
+func broadcast(left, right Value, fn func(*big.Float, *big.Float) (Value, error)) (Value, error) {
+	return combine(left, right, func(left, right Value) (Value, error) {
+		x, err := scalar(left)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := scalar(right)
+		if err != nil {
+			return nil, err
+		}
+
+		return fn(x, y)
+	})
+}
+
+// each applies fn to every element of value, which is not a list, retaining the structure of nested lists.
+func each(value Value, fn func(Value) (Value, error)) (Value, error) {
+	list, ok := value.(List)
+	if !ok {
+		return fn(value)
+	}
+
+	result := make(List, len(list))
+	for i, element := range list {
+		var err error
+		if result[i], err = each(element, fn); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// combine applies fn element-wise to the elements of left and right, which are not lists.
+// A scalar is combined with every element of a list, lists are combined element by element
+// and must therefore have the same length.
+func combine(left, right Value, fn func(Value, Value) (Value, error)) (Value, error) {
+	leftList, leftIsList := left.(List)
+	rightList, rightIsList := right.(List)
+
+	switch {
+	case leftIsList && rightIsList:
+		if len(leftList) != len(rightList) {
+			return nil, fmt.Errorf("list lengths %d and %d do not match", len(leftList), len(rightList))
+		}
+
+		result := make(List, len(leftList))
+		for i := range leftList {
+			var err error
+			if result[i], err = combine(leftList[i], rightList[i], fn); err != nil {
+				return nil, err
+			}
+		}
+
+		return result, nil
+
+	case leftIsList:
+		return each(left, func(x Value) (Value, error) { return combine(x, right, fn) })
+
+	case rightIsList:
+		return each(right, func(y Value) (Value, error) { return combine(left, y, fn) })
+
+	}
+
+	return fn(left, right)
+}
+
+// matrix converts the rows of a matrix to a list of lists.
+func matrix(rows [][]*big.Float) List {
+	list := make(List, len(rows))
+	for i, row := range rows {
+		list[i] = scalars(row)
+	}
+
+	return list
+}
+
+// scalars converts the scalars to a list of values.
+func scalars(floats []*big.Float) List {
+	list := make(List, len(floats))
+	for i, f := range floats {
+		list[i] = f
+	}
+
+	return list
+}
+
+// vector converts the value to a list of numbers.
+// It fails if the value is not a list or if it contains nested lists.
+func vector(value Value) ([]*big.Float, error) {
+	list, ok := value.(List)
+	if !ok {
+		return nil, fmt.Errorf("matrix rows must be lists")
+	}
+
+	row := make([]*big.Float, len(list))
+	for i, element := range list {
+		if row[i], ok = Scalar(element); !ok {
+			return nil, fmt.Errorf("matrix entries must be numbers")
+		}
+	}
+
+	return row, nil
+}
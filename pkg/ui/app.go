@@ -13,16 +13,13 @@ Example:
 package ui
 
 import (
-	"context"
-	"fmt"
-	"math"
-	"math/big"
 	"sync"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 	"github.com/sarumaj/edu-taschenrechner/pkg/calc"
@@ -47,7 +44,7 @@ type App struct {
 	fyne.App
 	fyne.Window
 	fyne.Theme
-	memory.MemoryCell
+	memory.MemoryCellInterface[parser.Value]
 	changeListener chan fyne.Settings
 	objects        ObjectStorage
 }
@@ -56,64 +53,48 @@ type App struct {
 func (a *App) Build() {
 	a.Do(func() {
 		// define options for parser
-		options := []parser.Option{
-			parser.WithVar("ANS", a.MemoryCell.Get),
-			parser.WithConst("PI", big.NewFloat(math.Pi)),
-			parser.WithConst("E", big.NewFloat(math.E)),
-			parser.WithFunc("save", func(arg *big.Float) (*big.Float, error) {
-				if err := a.MemoryCell.Set(arg); err != nil {
-					return nil, err
-				}
-				return a.MemoryCell.Get(), nil
-			}),
-			parser.WithFunc("sin", math.Sin),
-			parser.WithFunc("cos", math.Cos),
-			parser.WithFunc("tan", math.Tan),
-			parser.WithFunc("arcsin", func(f float64) (float64, error) {
-				if f < -1 || f > 1 {
-					return 0, fmt.Errorf("arcsin(%g) is undefined", f)
-				}
-				return math.Asin(f), nil
-			}),
-			parser.WithFunc("arccos", func(f float64) (float64, error) {
-				if f < -1 || f > 1 {
-					return 0, fmt.Errorf("arccos(%g) is undefined", f)
-				}
-				return math.Acos(f), nil
-			}),
-			parser.WithFunc("arctan", math.Atan),
-			parser.WithFunc("log", func(f float64) (float64, error) {
-				if f <= 0 {
-					return 0, fmt.Errorf("log(%g) is undefined", f)
-				}
-				return math.Log10(f), nil
-			}),
-			parser.WithFunc("ln", func(f float64) (float64, error) {
-				if f <= 0 {
-					return 0, fmt.Errorf("ln(%g) is undefined", f)
-				}
-				return math.Log(f), nil
-			}),
-			parser.WithFunc("gdc", func(x, y *big.Float) (*big.Float, error) {
-				return calc.GreatestCommonDivisor(context.Background(), x, y)
-			}),
-			parser.WithFunc("lcm", func(x, y *big.Float) (*big.Float, error) {
-				return calc.LeastCommonMultiple(context.Background(), x, y)
-			}),
-			parser.WithReplacements("×", "*", "÷", "/", "π", "PI", "e", "E"),
-		}
+		options := parserOptions(a.MemoryCellInterface)
 
-		// make display using options
+		// make display, statistics, matrix and regression panel using options
 		a.objects["display"] = NewDisplay("_", options...)
+		a.objects["statistics"] = NewStatisticsPanel(options...)
+		a.objects["matrix"] = NewMatrixPanel(options...).SetOnStored(func(value parser.Value) {
+			if err := a.MemoryCellInterface.Set(value); err != nil {
+				dialog.ShowError(err, a.Window)
+				return
+			}
+
+			// evaluate the memory cell to show the matrix in the display
+			display := a.objects.SelectDisplay("display")
+			display.Entry.SetText("ANS_")
+			display.SetText("=")
+		})
+		a.objects["regression"] = NewRegressionPanel(options...).SetOnStored(func(name string, fit *calc.Regression) {
+			// register the fitted model as a prediction function of the display
+			display := a.objects.SelectDisplay("display")
+			display.SetParserOptions(append(display.GetParserOptions(),
+				parser.WithFunc(name, fit.Predict),
+				parser.WithApproximate(name),
+				parser.WithDoc(name, doc("regression", "prediction of the fitted "+string(fit.Model)+" model "+fit.String(), name+"(1)")),
+			)...)
+
+			// make the prediction function available in the stat dropdown
+			a.objects[name] = NewButton(name, display).SetOnTapped(func() { display.SetText(name + "(") })
+			for _, dropdown := range a.objects.SelectDropDowns("stat") {
+				dropdown.Append(a.objects.SelectButtons(name)...)
+			}
+		})
 
 		// make buttons (some with alternate text)
 		for _, btnText := range append(runes.Each("1234567890+-×÷=.π!e°√"),
 			"xⁿ", "AC", "()", "↩",
-			"sin", "cos", "tan", "log", "ln", "gdc") {
+			"sin", "cos", "tan", "log", "ln", "gdc",
+			"normpdf", "normcdf", "binompdf", "poissonpdf", "tcdf", "chi2cdf", "erf") {
 
 			a.objects[btnText] = NewButton(btnText, a.objects.SelectDisplay("display")).
 				SetAlternateText(map[string]string{
 					"√":   "x²",
+					"-":   "±",
 					"sin": "sin⁻¹",
 					"cos": "cos⁻¹",
 					"tan": "tan⁻¹",
@@ -122,6 +103,11 @@ func (a *App) Build() {
 					"°":   "1/°",
 					".":   ",",
 					"gdc": "lcm",
+
+					"normcdf":    "invnorm",
+					"binompdf":   "binomcdf",
+					"poissonpdf": "poissoncdf",
+					"erf":        "erfc",
 				}[btnText])
 		}
 
@@ -129,12 +115,23 @@ func (a *App) Build() {
 		for name, relations := range map[string][]string{
 			"const": {"π", "e"},
 			"func":  {"sin", "cos", "tan", "log", "ln", "gdc"},
+			"stat":  {"normpdf", "normcdf", "binompdf", "poissonpdf", "tcdf", "chi2cdf", "erf"},
 		} {
 			a.objects[name] = NewButtonDropDown(a.objects.SelectButtons(relations...))
 		}
 
 		// make toolbars
-		var actions []widget.ToolbarItem
+		actions := []widget.ToolbarItem{
+			NewToolbarItem(theme.ListIcon()).SetOnTapped(func() {
+				a.objects.SelectStatisticsPanel("statistics").ShowDialog(a.Window)
+			}),
+			NewToolbarItem(theme.GridIcon()).SetOnTapped(func() {
+				a.objects.SelectRegressionPanel("regression").ShowDialog(a.Window)
+			}),
+			NewToolbarItem(theme.ViewFullScreenIcon()).SetOnTapped(func() {
+				a.objects.SelectMatrixPanel("matrix").ShowDialog(a.Window)
+			}),
+		}
 		for link, resources := range map[string][]fyne.Resource{
 			githubLink:   {resourceGithubPng, resourceGithubWhitePng},
 			linkedinLink: {resourceLinkedinPng, nil},
@@ -170,7 +167,7 @@ func (a *App) Build() {
 			container.NewGridWithColumns(3,
 				container.NewGridWithColumns(2, a.objects.SelectCanvasObjects("√", "xⁿ")...),
 				container.NewGridWithColumns(2, append(a.objects.SelectCanvasObjects("!"), a.objects["const"])...),
-				a.objects["func"],
+				container.NewGridWithColumns(2, a.objects.SelectCanvasObjects("func", "stat")...),
 			),
 			container.NewGridWithColumns(2,
 				container.NewGridWithColumns(3, a.objects.SelectCanvasObjects("INV", "AC", "↩")...),
@@ -244,11 +241,11 @@ func NewApp(title string) *App {
 	w.Resize(fyne.NewSize(600, 400))
 
 	i := &App{
-		App:            a,
-		changeListener: make(chan fyne.Settings),
-		MemoryCell:     memory.NewMemoryCell(),
-		Window:         w,
-		objects:        make(ObjectStorage),
+		App:                 a,
+		changeListener:      make(chan fyne.Settings),
+		MemoryCellInterface: memory.NewGenericMemoryCell[parser.Value](),
+		Window:              w,
+		objects:             make(ObjectStorage),
 	}
 
 	a.Settings().AddChangeListener(i.changeListener)
@@ -3,8 +3,10 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"time"
 
@@ -31,18 +33,62 @@ var _ interface {
 } = (*Display)(nil)
 
 // Display is a custom label widget that extends the default label with a memory cell.
+// Results are preceded by = if they are exact, by ≈ if they are approximate and by ∈ if they are intervals enclosing them.
 type Display struct {
 	widget.Entry
 	parserOpts           []parser.Option
+	parsers              map[parserConfig]parser.Parser // configured parsers, see configuredParser
+	result               parser.Value                   // displayed result, see ShowDigits
+	exact                bool                           // whether the displayed result is exact
+	approximate          bool                           // whether the memory cell ANS holds an approximate result
+	intervals            bool                           // whether interval arithmetic is enabled, see ToggleIntervals
 	MaximumContentLength int
 }
 
+// parserConfig is the configuration of a parser of the display in addition to its parser options.
+type parserConfig struct {
+	approximate bool // whether the memory cell ANS is approximate
+	intervals   bool // whether interval arithmetic is enabled
+}
+
+// relations precede exact, approximate and enclosed results in the display
+const (
+	exactRelation       = "= "
+	approximateRelation = "≈ "
+	enclosedRelation    = "∈ "
+)
+
 // Cursor returns the default cursor.
 func (*Display) Cursor() desktop.Cursor { return desktop.DefaultCursor }
 
+// configuredParser returns the parser configured with the parser options, the approximation of the memory cell
+// and interval arithmetic. The parsers are kept along with their caches of results of pure functions,
+// see parser.WithCache, until the parser options are changed.
+func (display *Display) configuredParser() parser.Parser {
+	config := parserConfig{approximate: display.approximate, intervals: display.intervals}
+	if p, ok := display.parsers[config]; ok {
+		return p
+	}
+
+	options := display.parserOpts[:len(display.parserOpts):len(display.parserOpts)]
+	if config.approximate {
+		options = append(options, parser.WithApproximate("ANS"))
+	}
+	if config.intervals {
+		options = append(options, parser.WithIntervals())
+	}
+
+	if display.parsers == nil {
+		display.parsers = make(map[parserConfig]parser.Parser)
+	}
+	display.parsers[config] = parser.NewParser(options...)
+
+	return display.parsers[config]
+}
+
 // CopyToClipboard copies the text of the display widget to the clipboard.
 func (display *Display) CopyToClipboard() {
-	fyne.CurrentApp().Driver().AllWindows()[0].Clipboard().SetContent(strings.TrimSuffix(display.Text, "_"))
+	fyne.CurrentApp().Driver().AllWindows()[0].Clipboard().SetContent(display.expression())
 }
 
 // GetParserOptions returns the parser options of the display widget.
@@ -54,17 +100,40 @@ func (display *Display) GetText() string { return display.Entry.Text }
 // GetOnChanged returns a function that sets the cursor to the end of the text.
 func (display *Display) GetOnChanged() func(string) {
 	return func(text string) {
-		column := len([]rune(text))
+		lines := strings.Split(text, "\n")
+		column := len([]rune(lines[len(lines)-1]))
 		if strings.HasSuffix(text, "_") {
 			column--
 		}
 
+		display.Entry.CursorRow = len(lines) - 1
 		display.Entry.CursorColumn = column
 		display.Entry.FocusGained()
 		display.Entry.Refresh()
 	}
 }
 
+// relation returns the relation preceding a result, i.e., ∈ if it is an interval, = if it is exact and ≈ otherwise.
+func relation(result parser.Value, exact bool) string {
+	if _, ok := result.(parser.Interval); ok {
+		return enclosedRelation
+	}
+
+	if exact {
+		return exactRelation
+	}
+
+	return approximateRelation
+}
+
+// expression returns the text of the display widget without the cursor and the relation preceding a result.
+func (display *Display) expression() string {
+	text := strings.TrimSuffix(display.Text, "_")
+	text = strings.TrimPrefix(text, exactRelation)
+	text = strings.TrimPrefix(text, enclosedRelation)
+	return strings.TrimPrefix(text, approximateRelation)
+}
+
 // MeasureDisplayCapacity measures the display capacity interactively.
 // It shows a dialog to ask the user if the result is visible in the display.
 // If the measurement is completed, it shows an information dialog with the result.
@@ -92,21 +161,181 @@ func (display *Display) NewMeasurement(title string, lo, hi int) *measurement {
 	}
 }
 
+// ShowDigits shows the displayed result with all guaranteed digits in a dialog, see parser.Digits,
+// i.e., the complete decimal expansion of exact results and the significant digits of the precision of approximate ones.
+func (display *Display) ShowDigits() {
+	window := fyne.CurrentApp().Driver().AllWindows()[0]
+	if display.result == nil {
+		dialog.ShowInformation("Guaranteed digits", "Evaluate an expression to show all guaranteed digits of its result.", window)
+		return
+	}
+
+	label := widget.NewLabelWithStyle(relation(display.result, display.exact)+parser.Digits(display.result, display.exact), fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	label.Wrapping = fyne.TextWrapBreak
+
+	info := dialog.NewCustom("Guaranteed digits", "Close", container.NewVScroll(label), window)
+	info.Resize(fyne.NewSize(window.Canvas().Size().Width*0.9, window.Canvas().Size().Height*0.5))
+	info.Show()
+}
+
+// ShowNotations shows the expression of the display widget in infix, Unicode, LaTeX and MathML notation
+// in a dialog, from which each notation can be copied to the clipboard.
+// Open brackets are closed before the expression is parsed.
+func (display *Display) ShowNotations() {
+	window := fyne.CurrentApp().Driver().AllWindows()[0]
+	text := display.expression()
+	text += strings.Repeat(")", runes.HowManyOpen(runes.NewSequence(text)))
+
+	root, err := display.configuredParser().Tree(text)
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	form := widget.NewForm()
+	for _, notation := range []struct {
+		label    string
+		notation parser.Notation
+	}{
+		{"Text", parser.Infix},
+		{"Unicode", parser.Unicode},
+		{"LaTeX", parser.LaTeX},
+		{"MathML", parser.MathML},
+	} {
+		formatted := parser.Format(root, notation.notation)
+		label := widget.NewLabelWithStyle(formatted, fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+		label.Wrapping = fyne.TextWrapBreak
+		copyButton := widget.NewButtonWithIcon("", theme.ContentCopyIcon(), func() { window.Clipboard().SetContent(formatted) })
+		form.Append(notation.label, container.NewBorder(nil, nil, nil, copyButton, label))
+	}
+
+	info := dialog.NewCustom("Notations", "Close", container.NewVScroll(form), window)
+	info.Resize(fyne.NewSize(window.Canvas().Size().Width*0.9, window.Canvas().Size().Height*0.9))
+	info.Show()
+}
+
+// ShowHelp shows the documentation of the constants and functions of the parser of the display widget
+// grouped by their categories in a dialog, i.e., the signatures, domains, descriptions and examples, see parser.Doc.
+func (display *Display) ShowHelp() {
+	window := fyne.CurrentApp().Driver().AllWindows()[0]
+	p := display.configuredParser()
+
+	type entry struct {
+		title string
+		doc   parser.Doc
+	}
+
+	categories := map[string][]entry{}
+	for _, c := range p.ListConstants() {
+		categories[c.Category] = append(categories[c.Category], entry{title: c.Name, doc: c.Doc})
+	}
+	for _, f := range p.ListFunctions() {
+		categories[f.Category] = append(categories[f.Category], entry{title: f.Signature(), doc: f.Doc})
+	}
+
+	names := make([]string, 0, len(categories))
+	for name := range categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	content := container.NewVBox()
+	for _, name := range names {
+		if name == "" {
+			continue // undocumented
+		}
+
+		form := widget.NewForm()
+		for _, e := range categories[name] {
+			lines := []string{e.doc.Description}
+			if e.doc.Domain != "" {
+				lines = append(lines, "Domain: "+e.doc.Domain)
+			}
+			if len(e.doc.Examples) > 0 {
+				lines = append(lines, "Examples: "+strings.Join(e.doc.Examples, ", "))
+			}
+
+			label := widget.NewLabel(strings.Join(lines, "\n"))
+			label.Wrapping = fyne.TextWrapWord
+			form.Append(e.title, label)
+		}
+
+		content.Add(widget.NewLabelWithStyle(name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+		content.Add(form)
+	}
+
+	info := dialog.NewCustom("Help", "Close", container.NewVScroll(content), window)
+	info.Resize(fyne.NewSize(window.Canvas().Size().Width*0.9, window.Canvas().Size().Height*0.9))
+	info.Show()
+}
+
+// ShowWork shows the evaluation of the expression of the display widget step by step in a dialog,
+// i.e., the successive expressions in which the evaluated subexpressions are replaced by their values.
+// Open brackets are closed before the expression is parsed.
+func (display *Display) ShowWork() {
+	window := fyne.CurrentApp().Driver().AllWindows()[0]
+	text := display.expression()
+	text += strings.Repeat(")", runes.HowManyOpen(runes.NewSequence(text)))
+
+	var steps []parser.Step
+	p := display.configuredParser().With(parser.WithObserver(func(step parser.Step) { steps = append(steps, step) }))
+	root, err := p.Tree(text)
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if _, err := root.Evaluate(ctx, p); err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	expressions := parser.Rewrite(root, steps, parser.Unicode)
+	for i := 1; i < len(expressions); i++ {
+		expressions[i] = "→ " + expressions[i]
+	}
+
+	label := widget.NewLabelWithStyle(strings.Join(expressions, "\n"), fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	label.Wrapping = fyne.TextWrapBreak
+
+	info := dialog.NewCustom("Show work", "Close", container.NewVScroll(label), window)
+	info.Resize(fyne.NewSize(window.Canvas().Size().Width*0.9, window.Canvas().Size().Height*0.9))
+	info.Show()
+}
+
 // SetMaximumContentLength sets the maximum content length of the display widget.
 func (display *Display) SetMaximumContentLength(length int) *Display {
 	display.MaximumContentLength = length
 	return display
 }
 
+// SetMultiLine sets the number of visible rows of the display widget.
+// The display is switched to multi-line mode if more than one row is requested, e.g., to show a matrix.
+func (display *Display) SetMultiLine(rows int) *Display {
+	display.Entry.MultiLine = rows > 1
+	if display.Entry.MultiLine {
+		display.Entry.Scroll = container.ScrollBoth
+	} else {
+		display.Entry.Scroll = container.ScrollHorizontalOnly
+	}
+
+	display.Entry.SetMinRowsVisible(rows)
+	return display
+}
+
 // SetOnChanged sets the OnChanged function of the display widget.
 func (display *Display) SetOnChanged(fn func(string)) *Display {
 	display.Entry.OnChanged = fn
 	return display
 }
 
-// SetParserOptions sets the parser options of the display widget.
+// SetParserOptions sets the parser options of the display widget, which discards the configured parsers.
 func (display *Display) SetParserOptions(options ...parser.Option) *Display {
 	display.parserOpts = options
+	display.parsers = nil
 	return display
 }
 
@@ -114,8 +343,8 @@ func (display *Display) SetParserOptions(options ...parser.Option) *Display {
 // It moves the cursor to the end of the text and checks the state of the cursor.
 // If the cursor is in an invalid state, it shows an error dialog.
 func (display *Display) SetText(text string) {
-	// create a new cursor
-	textCursor := cursor.New(runes.NewSequence(display.Text), time.Minute, display.parserOpts...)
+	// create a new cursor, the memory cell is approximate if the last result was
+	textCursor := cursor.NewWithParser(runes.NewSequence(display.Text), time.Minute, display.configuredParser())
 
 	if text == "=" && Interactive { // Display cancelable waiting dialog when calculating
 		window := fyne.CurrentApp().Driver().AllWindows()[0]
@@ -136,18 +365,37 @@ func (display *Display) SetText(text string) {
 		// perform the operation on the cursor
 		result := textCursor.Do(text).String()
 
+		// render matrices as a multi-row grid, since they are not evaluated from the display text anymore
+		rows, isMatrix := matrixRows(textCursor.Result())
+		if isMatrix {
+			result = strings.Join(formatMatrix(rows, 'g', 10), "\n")
+		}
+
+		// render intervals with 10 significant digits, their bounds are rounded outwards
+		if interval, ok := textCursor.Result().(parser.Interval); ok && textCursor.Check() == nil {
+			result = parser.Text(interval, 'g', 10)
+		}
+
 		// on first exceedance of the maximum content length, show the current value in scientific notation
-		if display.MaximumContentLength > 0 && len(result) > display.MaximumContentLength {
+		if !isMatrix && display.MaximumContentLength > 0 && len(result) > display.MaximumContentLength {
 			// exploit the capability of the memory cell to display the result in scientific notation
 			result = textCursor.EqualsWithFormat('g').String()
 		}
 
 		// on second exceedance of the maximum content length, truncate the result
-		if display.MaximumContentLength > 0 && len(result) > display.MaximumContentLength {
+		if !isMatrix && display.MaximumContentLength > 0 && len(result) > display.MaximumContentLength {
 			result = result[:display.MaximumContentLength-3] + "..."
 		}
 
+		// precede results by their relation, i.e., ∈ if they are intervals, = if they are exact and ≈ otherwise
+		display.result, display.exact = textCursor.Result(), textCursor.Exact()
+		if display.result != nil {
+			display.approximate = !display.exact
+			result = relation(display.result, display.exact) + strings.ReplaceAll(result, "\n", "\n"+strings.Repeat(" ", len(exactRelation)))
+		}
+
 		// perform the calculation and set the result
+		display.SetMultiLine(max(len(rows), 1))
 		display.Entry.SetText(result)
 
 		// schedule an update of the entry on the main thread
@@ -179,6 +427,13 @@ func (display *Display) SetText(text string) {
 	}
 }
 
+// ToggleIntervals enables or disables interval arithmetic, see parser.WithIntervals, and reports whether it is enabled.
+// List literals of two numbers denote intervals then, e.g., [9.8, 9.81], and results are guaranteed to be enclosed.
+func (display *Display) ToggleIntervals() bool {
+	display.intervals = !display.intervals
+	return display.intervals
+}
+
 // Overwrite methods to prevent the display from being editable
 func (*Display) DoubleTapped(*fyne.PointEvent)    {}
 func (*Display) KeyDown(*fyne.KeyEvent)           {}
@@ -22,6 +22,13 @@ type ButtonDropDown struct {
 	buttons []*Button
 }
 
+// Append appends the given buttons to the dropdown and updates it.
+func (b *ButtonDropDown) Append(buttons ...*Button) *ButtonDropDown {
+	b.buttons = append(b.buttons, buttons...)
+	b.Update()
+	return b
+}
+
 // Cursor returns the pointer cursor.
 func (*ButtonDropDown) Cursor() desktop.Cursor { return desktop.PointerCursor }
 
@@ -0,0 +1,232 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/sarumaj/edu-taschenrechner/pkg/calc"
+	"github.com/sarumaj/edu-taschenrechner/pkg/memory"
+	"github.com/sarumaj/edu-taschenrechner/pkg/parser"
+)
+
+// parserOptions returns the options of the parsers of the calculator, i.e., its documented constants and functions.
+// The memory cell is available as the variable ANS and results are stored in it by the save function.
+// The results of pure functions are cached by the parsers, which the display and the panels keep across evaluations.
+func parserOptions(cell memory.MemoryCellInterface[parser.Value]) []parser.Option {
+	return []parser.Option{
+		parser.WithValueVar("ANS", cell.Get),
+		parser.WithConst("PI", big.NewFloat(math.Pi)),
+		parser.WithDoc("PI", doc("constants", "", "ratio of the circumference of a circle to its diameter", "π", "2*π")),
+		parser.WithConst("E", big.NewFloat(math.E)),
+		parser.WithDoc("E", doc("constants", "", "Euler's number, the base of the natural logarithm", "e", "e^2")),
+		parser.WithFunc("save", func(args ...parser.Value) (parser.Value, error) {
+			if err := cell.Set(args[0]); err != nil {
+				return nil, err
+			}
+			return cell.Get(), nil
+		}),
+		parser.WithArity("save", 1, 1),
+		parser.WithDoc("save", doc("memory", "any value x", "stores x in the memory cell ANS and returns it", "save(42)", "save([1, 2, 3])")),
+		parser.WithFunc("sin", math.Sin),
+		parser.WithDoc("sin", doc("trigonometry", "x ∈ ℝ", "sine of the angle x in radians", "sin(π/2)", "sin(30°)")),
+		parser.WithFunc("cos", math.Cos),
+		parser.WithDoc("cos", doc("trigonometry", "x ∈ ℝ", "cosine of the angle x in radians", "cos(π)", "cos(60°)")),
+		parser.WithFunc("tan", math.Tan),
+		parser.WithDoc("tan", doc("trigonometry", "x ≠ π/2 + k*π for integers k", "tangent of the angle x in radians", "tan(π/4)")),
+		parser.WithFunc("arcsin", func(f float64) (float64, error) {
+			if f < -1 || f > 1 {
+				return 0, fmt.Errorf("arcsin(%g) is undefined", f)
+			}
+			return math.Asin(f), nil
+		}),
+		parser.WithDoc("arcsin", doc("trigonometry", "-1 ≤ x ≤ 1", "inverse sine of x in radians", "arcsin(1)")),
+		parser.WithFunc("arccos", func(f float64) (float64, error) {
+			if f < -1 || f > 1 {
+				return 0, fmt.Errorf("arccos(%g) is undefined", f)
+			}
+			return math.Acos(f), nil
+		}),
+		parser.WithDoc("arccos", doc("trigonometry", "-1 ≤ x ≤ 1", "inverse cosine of x in radians", "arccos(0)")),
+		parser.WithFunc("arctan", math.Atan),
+		parser.WithDoc("arctan", doc("trigonometry", "x ∈ ℝ", "inverse tangent of x in radians", "arctan(1)")),
+		parser.WithFunc("log", func(f float64) (float64, error) {
+			if f <= 0 {
+				return 0, fmt.Errorf("log(%g) is undefined", f)
+			}
+			return math.Log10(f), nil
+		}),
+		parser.WithDoc("log", doc("logarithms", "x > 0", "common logarithm of x to the base 10", "log(1000)")),
+		parser.WithFunc("ln", func(f float64) (float64, error) {
+			if f <= 0 {
+				return 0, fmt.Errorf("ln(%g) is undefined", f)
+			}
+			return math.Log(f), nil
+		}),
+		parser.WithDoc("ln", doc("logarithms", "x > 0", "natural logarithm of x", "ln(e)")),
+		parser.WithFunc("gdc", calc.GreatestCommonDivisor),
+		parser.WithDoc("gdc", doc("number theory", "integers x1, x2, ...", "greatest common divisor of x1, x2, ...", "gdc(12, 18)", "gdc(12, 18, 8)")),
+		parser.WithFunc("lcm", calc.LeastCommonMultiple),
+		parser.WithDoc("lcm", doc("number theory", "integers x1, x2, ...", "least common multiple of x1, x2, ...", "lcm(4, 6)", "lcm(4, 6, 10)")),
+		parser.WithFunc("isprime", calc.IsPrime),
+		parser.WithDoc("isprime", doc("number theory", "integer n", "1 if n is a prime number, 0 otherwise", "isprime(97)")),
+		parser.WithFunc("nextprime", calc.NextPrime),
+		parser.WithDoc("nextprime", doc("number theory", "integer n", "smallest prime number greater than n", "nextprime(100)")),
+		parser.WithFunc("factor", calc.Factor),
+		parser.WithDoc("factor", doc("number theory", "integer n ≥ 1", "prime factors of n in ascending order", "factor(360)")),
+		parser.WithFunc("divisors", calc.Divisors),
+		parser.WithDoc("divisors", doc("number theory", "integer n ≠ 0", "positive divisors of n in ascending order", "divisors(12)")),
+		parser.WithFunc("modpow", func(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+			return calc.ModPow(ctx, args[0], args[1], args[2])
+		}),
+		parser.WithArity("modpow", 3, 3),
+		parser.WithDoc("modpow", doc("number theory", "integers b, e and m ≥ 1, e < 0 if b is invertible modulo m", "b^e mod m, modpow(b, e, m)", "modpow(2, 10, 1000)", "modpow(3, -1, 7)")),
+		parser.WithFunc("modinv", calc.ModInverse),
+		parser.WithDoc("modinv", doc("number theory", "integers a and m ≥ 1, a coprime to m", "inverse x of a modulo m, i.e., a*x ≡ 1 (mod m), modinv(a, m)", "modinv(3, 7)")),
+		parser.WithFunc("totient", calc.Totient),
+		parser.WithDoc("totient", doc("number theory", "integer n ≥ 1", "Euler's totient φ(n), the count of integers in [1, n] coprime to n", "totient(36)")),
+		parser.WithFunc("binomial", calc.Binomial),
+		parser.WithDoc("binomial", doc("combinatorics", "integers n ≥ 0 and k ≥ 0", "binomial coefficient n!/(k!*(n-k)!), binomial(n, k)", "binomial(5, 2)")),
+		parser.WithFunc("nCr", calc.Binomial),
+		parser.WithDoc("nCr", doc("combinatorics", "integers n ≥ 0 and k ≥ 0", "number of combinations of k out of n elements, see binomial, nCr(n, k)", "nCr(49, 6)")),
+		parser.WithFunc("nPr", calc.Permutations),
+		parser.WithDoc("nPr", doc("combinatorics", "integers n ≥ 0 and k ≥ 0", "number of permutations of k out of n elements, i.e., n!/(n-k)!, nPr(n, k)", "nPr(5, 2)")),
+		parser.WithFunc("fib", calc.Fibonacci),
+		parser.WithDoc("fib", doc("combinatorics", "integer n", "n-th Fibonacci number, including negative n", "fib(10)", "fib(-5)")),
+		parser.WithFunc("sum", calc.Sum),
+		parser.WithDoc("sum", doc("statistics", "numbers or lists x1, x2, ...", "sum of the values x1, x2, ... or of the elements of a list", "sum(1, 2, 3)", "sum([1, 2, 3])")),
+		parser.WithFunc("mean", calc.Mean),
+		parser.WithDoc("mean", doc("statistics", "at least one value", "arithmetic mean", "mean(1, 2, 3, 4)")),
+		parser.WithFunc("median", calc.Median),
+		parser.WithDoc("median", doc("statistics", "at least one value", "median, the mean of the two middle values for an even count", "median(3, 1, 2)", "median(1, 2, 3, 4)")),
+		parser.WithFunc("mode", calc.Mode),
+		parser.WithDoc("mode", doc("statistics", "at least one value", "most frequent value, the smallest one of several equally frequent values", "mode(1, 2, 2, 3)")),
+		parser.WithFunc("var", calc.SampleVariance),
+		parser.WithDoc("var", doc("statistics", "at least two values", "sample variance", "var(1, 2, 3, 4)")),
+		parser.WithFunc("varp", calc.PopulationVariance),
+		parser.WithDoc("varp", doc("statistics", "at least one value", "population variance", "varp(1, 2, 3, 4)")),
+		parser.WithFunc("stdev", calc.SampleStandardDeviation),
+		parser.WithDoc("stdev", doc("statistics", "at least two values", "sample standard deviation", "stdev(2, 4, 4, 4, 5, 5, 7, 9)")),
+		parser.WithFunc("stdevp", calc.PopulationStandardDeviation),
+		parser.WithDoc("stdevp", doc("statistics", "at least one value", "population standard deviation", "stdevp(2, 4, 4, 4, 5, 5, 7, 9)")),
+		parser.WithFunc("min", calc.Minimum),
+		parser.WithDoc("min", doc("statistics", "at least one value", "smallest value", "min(3, 1, 2)")),
+		parser.WithFunc("max", calc.Maximum),
+		parser.WithDoc("max", doc("statistics", "at least one value", "largest value", "max(3, 1, 2)")),
+		parser.WithFunc("range", calc.Range),
+		parser.WithDoc("range", doc("statistics", "at least one value", "difference between the largest and the smallest value", "range(3, 1, 2)")),
+		parser.WithFunc("quantile", func(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+			return calc.Quantile(ctx, args[0], args[1:]...)
+		}),
+		parser.WithArity("quantile", 2, -1),
+		parser.WithDoc("quantile", doc("statistics", "0 ≤ p ≤ 1 and at least one value", "p-quantile by linear interpolation, quantile(p, x1, x2, ...)", "quantile(0.25, 1, 2, 3, 4)")),
+		parser.WithFunc("dot", calc.Dot),
+		parser.WithDoc("dot", doc("vectors", "two vectors of the same length", "dot product of two vectors", "dot([1, 2, 3], [4, 5, 6])")),
+		parser.WithFunc("cross", calc.Cross),
+		parser.WithDoc("cross", doc("vectors", "two vectors of length 3", "cross product of two vectors", "cross([1, 0, 0], [0, 1, 0])")),
+		parser.WithFunc("norm", calc.Norm),
+		parser.WithDoc("norm", doc("vectors", "vector", "Euclidean norm of a vector", "norm([3, 4])")),
+		parser.WithFunc("transpose", calc.Transpose),
+		parser.WithDoc("transpose", doc("matrices", "matrix given as a list of rows", "transpose of a matrix", "transpose([[1, 2], [3, 4]])")),
+		parser.WithFunc("det", calc.Determinant),
+		parser.WithDoc("det", doc("matrices", "square matrix", "determinant of a matrix", "det([[1, 2], [3, 4]])")),
+		parser.WithFunc("inv", calc.Inverse),
+		parser.WithDoc("inv", doc("matrices", "regular square matrix", "inverse of a matrix", "inv([[1, 2], [3, 4]])")),
+		parser.WithFunc("rank", calc.Rank),
+		parser.WithDoc("rank", doc("matrices", "matrix", "rank of a matrix, i.e., the number of its linearly independent rows", "rank([[1, 2], [2, 4]])")),
+		parser.WithFunc("solve", calc.Solve),
+		parser.WithDoc("solve", doc("matrices", "regular square matrix A and vector b of the same size", "solution x of A*x = b, solve(A, b)", "solve([[2, 1], [1, 3]], [3, 5])")),
+		parser.WithFunc("normpdf", func(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+			args, err := normal("normpdf", 1, args...)
+			if err != nil {
+				return nil, err
+			}
+			return calc.NormalPDF(ctx, args[0], args[1], args[2])
+		}),
+		parser.WithDoc("normpdf", doc("distributions", "x ∈ ℝ and σ > 0", "normal density at x with μ = 0 and σ = 1 by default, normpdf(x[, μ, σ])", "normpdf(0)", "normpdf(110, 100, 15)")),
+		parser.WithFunc("normcdf", func(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+			args, err := normal("normcdf", 2, args...)
+			if err != nil {
+				return nil, err
+			}
+			return calc.NormalCDF(ctx, args[0], args[1], args[2], args[3])
+		}),
+		parser.WithDoc("normcdf", doc("distributions", "a ≤ b and σ > 0", "normal probability P(a ≤ X ≤ b) with μ = 0 and σ = 1 by default, normcdf(a, b[, μ, σ])", "normcdf(-1, 1)", "normcdf(85, 115, 100, 15)")),
+		parser.WithFunc("invnorm", func(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+			args, err := normal("invnorm", 1, args...)
+			if err != nil {
+				return nil, err
+			}
+			return calc.InverseNormal(ctx, args[0], args[1], args[2])
+		}),
+		parser.WithDoc("invnorm", doc("distributions", "0 < p < 1 and σ > 0", "normal quantile x with P(X ≤ x) = p and μ = 0 and σ = 1 by default, invnorm(p[, μ, σ])", "invnorm(0.975)", "invnorm(0.5, 100, 15)")),
+		parser.WithFunc("binompdf", func(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+			return calc.BinomialPDF(ctx, args[0], args[1], args[2])
+		}),
+		parser.WithArity("binompdf", 3, 3),
+		parser.WithDoc("binompdf", doc("distributions", "integers n ≥ 0 and k, 0 ≤ p ≤ 1", "binomial probability P(X = k) of n trials with probability p, binompdf(n, p, k)", "binompdf(10, 0.5, 3)")),
+		parser.WithFunc("binomcdf", func(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+			return calc.BinomialCDF(ctx, args[0], args[1], args[2])
+		}),
+		parser.WithArity("binomcdf", 3, 3),
+		parser.WithDoc("binomcdf", doc("distributions", "integers n ≥ 0 and k, 0 ≤ p ≤ 1", "binomial probability P(X ≤ k) of n trials with probability p, binomcdf(n, p, k)", "binomcdf(10, 0.5, 3)")),
+		parser.WithFunc("poissonpdf", calc.PoissonPDF),
+		parser.WithDoc("poissonpdf", doc("distributions", "λ > 0 and integer k", "Poisson probability P(X = k) with mean λ, poissonpdf(λ, k)", "poissonpdf(2, 3)")),
+		parser.WithFunc("poissoncdf", calc.PoissonCDF),
+		parser.WithDoc("poissoncdf", doc("distributions", "λ > 0 and integer k", "Poisson probability P(X ≤ k) with mean λ, poissoncdf(λ, k)", "poissoncdf(2, 3)")),
+		parser.WithFunc("tcdf", func(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+			return calc.StudentTCDF(ctx, args[0], args[1], args[2])
+		}),
+		parser.WithArity("tcdf", 3, 3),
+		parser.WithDoc("tcdf", doc("distributions", "a ≤ b and integer df ≥ 1", "Student's t probability P(a ≤ T ≤ b) with df degrees of freedom, tcdf(a, b, df)", "tcdf(-2, 2, 10)")),
+		parser.WithFunc("chi2cdf", func(ctx context.Context, args ...*big.Float) (*big.Float, error) {
+			return calc.ChiSquareCDF(ctx, args[0], args[1], args[2])
+		}),
+		parser.WithArity("chi2cdf", 3, 3),
+		parser.WithDoc("chi2cdf", doc("distributions", "a ≤ b and integer df ≥ 1", "chi-squared probability P(a ≤ X ≤ b) with df degrees of freedom, chi2cdf(a, b, df)", "chi2cdf(0, 3.84, 1)")),
+		parser.WithFunc("erf", calc.Erf),
+		parser.WithDoc("erf", doc("special functions", "x ∈ ℝ", "error function 2/√π * ∫[0, x] e^(-t²) dt", "erf(0.5)")),
+		parser.WithFunc("erfc", calc.Erfc),
+		parser.WithDoc("erfc", doc("special functions", "x ∈ ℝ", "complementary error function 1 - erf(x), which is precise for large x", "erfc(3)")),
+		parser.WithPure(
+			"sin", "cos", "tan", "arcsin", "arccos", "arctan", "log", "ln",
+			"gdc", "lcm", "isprime", "nextprime", "factor", "divisors", "modpow", "modinv", "totient",
+			"binomial", "nCr", "nPr", "fib",
+			"sum", "mean", "median", "mode", "var", "varp", "stdev", "stdevp", "min", "max", "range", "quantile",
+			"dot", "cross", "norm", "transpose", "det", "inv", "rank", "solve",
+			"normpdf", "normcdf", "invnorm", "binompdf", "binomcdf", "poissonpdf", "poissoncdf", "tcdf", "chi2cdf",
+			"erf", "erfc",
+		),
+		parser.WithCache(256),
+		parser.WithFactorialLimit("binomial", "nCr", "nPr", "fib", "binompdf", "binomcdf"),
+		parser.WithApproximate(
+			"PI", "E",
+			"normpdf", "normcdf", "invnorm", "binompdf", "binomcdf", "poissonpdf", "poissoncdf", "tcdf", "chi2cdf",
+			"erf", "erfc",
+		),
+		parser.WithReplacements("×", "*", "÷", "/", "π", "PI", "e", "E"),
+	}
+}
+
+// doc documents a function or constant of the given category by the domain of its arguments,
+// which is empty for constants, its description and examples.
+func doc(category, domain, description string, examples ...string) parser.Doc {
+	return parser.Doc{Category: category, Domain: domain, Description: description, Examples: examples}
+}
+
+// normal completes the arguments of a normal distribution function with the standard parameters.
+// The required arguments are followed by the optional mean (default 0) and standard deviation (default 1).
+func normal(name string, required int, args ...*big.Float) ([]*big.Float, error) {
+	switch len(args) {
+	case required:
+		return append(args, big.NewFloat(0), big.NewFloat(1)), nil
+
+	case required + 2:
+		return args, nil
+
+	default:
+		return nil, fmt.Errorf("%s function requires %d or %d arguments", name, required, required+2)
+
+	}
+}
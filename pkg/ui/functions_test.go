@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sarumaj/edu-taschenrechner/pkg/memory"
+	"github.com/sarumaj/edu-taschenrechner/pkg/parser"
+)
+
+func TestParserOptionsDocs(t *testing.T) {
+	p := parser.NewParser(parserOptions(memory.NewGenericMemoryCell[parser.Value]())...)
+
+	docs := map[string]parser.Doc{}
+	for _, f := range p.ListFunctions() {
+		if f.Domain == "" {
+			t.Errorf("Domain of %s is not documented", f.Name)
+		}
+		docs[f.Name+"()"] = f.Doc
+	}
+	for _, c := range p.ListConstants() {
+		docs[c.Name] = c.Doc
+	}
+
+	for name, doc := range docs {
+		t.Run(name, func(t *testing.T) {
+			if doc.Category == "" || doc.Description == "" || len(doc.Examples) == 0 {
+				t.Fatalf("%s is not documented: %+v", name, doc)
+			}
+
+			for _, example := range doc.Examples {
+				if _, _, err := p.ParseExact(context.TODO(), example); err != nil {
+					t.Errorf("Error evaluating example %q of %s: %v", example, name, err)
+				}
+			}
+		})
+	}
+}
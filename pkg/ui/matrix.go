@@ -0,0 +1,280 @@
+//go:build !headless
+
+package ui
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/sarumaj/edu-taschenrechner/pkg/calc"
+	"github.com/sarumaj/edu-taschenrechner/pkg/parser"
+)
+
+// matrixLabels defines the order and the labels of the results shown in the matrix panel.
+var matrixLabels = []string{"det", "rank", "transpose", "inverse", "solution"}
+
+// MatrixPanel is a custom widget to enter a matrix A and a vector b and see the results of linear algebra at once.
+// Each line of the matrix holds one row, whose entries are separated by semicolons and may be arbitrary expressions.
+// The entries of the vector b are separated by semicolons as well.
+// The matrix can be stored in the memory cell to be used as ANS in expressions.
+type MatrixPanel struct {
+	widget.BaseWidget
+	data      *widget.Entry
+	vector    *widget.Entry
+	store     *widget.Button
+	status    *widget.Label
+	summary   map[string]*widget.Label
+	matrix    [][]*big.Float
+	onStored  func(value parser.Value)
+	evaluator parser.Parser
+}
+
+// CreateRenderer creates the renderer for the matrix panel.
+func (p *MatrixPanel) CreateRenderer() fyne.WidgetRenderer {
+	form := widget.NewForm(widget.NewFormItem("b", p.vector))
+	for _, label := range matrixLabels {
+		form.Append(label, p.summary[label])
+	}
+
+	return widget.NewSimpleRenderer(container.NewBorder(
+		widget.NewLabel("Enter one row of A per line and separate the entries with semicolons:"),
+		container.NewBorder(nil, nil, nil, p.store, p.status), nil, nil,
+		container.NewGridWithColumns(2, p.data, container.NewVScroll(form)),
+	))
+}
+
+// SetOnStored sets the function that is called when the matrix is stored in the memory cell.
+func (p *MatrixPanel) SetOnStored(fn func(value parser.Value)) *MatrixPanel {
+	p.onStored = fn
+	return p
+}
+
+// SetText sets the matrix of the matrix panel.
+func (p *MatrixPanel) SetText(text string) { p.data.SetText(text) }
+
+// ShowDialog displays the matrix panel in a dialog of the given window.
+func (p *MatrixPanel) ShowDialog(window fyne.Window) {
+	info := dialog.NewCustom("Matrices", "Close", p, window)
+	info.Resize(fyne.NewSize(window.Canvas().Size().Width*0.9, window.Canvas().Size().Height*0.9))
+	info.Show()
+}
+
+// Store stores the matrix in the memory cell.
+func (p *MatrixPanel) Store() {
+	if p.matrix == nil {
+		return
+	}
+
+	rows := make(parser.List, len(p.matrix))
+	for i, row := range p.matrix {
+		entries := make(parser.List, len(row))
+		for j, entry := range row {
+			entries[j] = entry
+		}
+		rows[i] = entries
+	}
+
+	if p.onStored != nil {
+		p.onStored(rows)
+	}
+
+	p.status.SetText("stored as ANS")
+}
+
+// Update evaluates the matrix and the vector and displays the results.
+// Results which are undefined for the given matrix, e.g., the inverse of a singular matrix, are left empty.
+func (p *MatrixPanel) Update() {
+	p.matrix = nil
+	p.store.Disable()
+	for _, label := range p.summary {
+		label.SetText("")
+	}
+
+	a, b, err := p.Values(p.data.Text, p.vector.Text)
+	if err == nil && len(a) == 0 {
+		p.status.SetText("")
+		return
+	}
+
+	if err == nil {
+		_, err = calc.Transpose(context.Background(), a) // validate the dimensions
+	}
+
+	if err != nil {
+		p.status.SetText(err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	p.matrix = a
+	p.status.SetText(fmt.Sprintf("%dx%d matrix", len(a), len(a[0])))
+	p.store.Enable()
+
+	if det, err := calc.Determinant(ctx, a); err == nil {
+		p.summary["det"].SetText(det.Text('g', 10))
+	}
+
+	if rank, err := calc.Rank(ctx, a); err == nil {
+		p.summary["rank"].SetText(rank.Text('g', 10))
+	}
+
+	if transpose, err := calc.Transpose(ctx, a); err == nil {
+		p.summary["transpose"].SetText(strings.Join(formatMatrix(transpose, 'g', 10), "\n"))
+	}
+
+	if inverse, err := calc.Inverse(ctx, a); err == nil {
+		p.summary["inverse"].SetText(strings.Join(formatMatrix(inverse, 'g', 10), "\n"))
+	}
+
+	if len(b) > 0 {
+		solution, err := calc.Solve(ctx, a, b)
+		if err != nil {
+			p.summary["solution"].SetText(err.Error())
+			return
+		}
+
+		p.summary["solution"].SetText(strings.Join(formatMatrix([][]*big.Float{solution}, 'g', 10), "\n"))
+	}
+}
+
+// Values evaluates the matrix and the vector given as text.
+// Empty lines of the matrix are skipped, lists contribute all their elements to the row or vector.
+func (p *MatrixPanel) Values(matrix, vector string) (a [][]*big.Float, b []*big.Float, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	entries := func(text string) ([]*big.Float, error) {
+		var floats []*big.Float
+		for j, entry := range strings.Split(text, ";") {
+			if strings.TrimSpace(entry) == "" {
+				continue
+			}
+
+			value, err := p.evaluator.ParseValue(ctx, entry)
+			if err != nil {
+				return nil, fmt.Errorf("entry #%d (%q): %w", j+1, strings.TrimSpace(entry), err)
+			}
+
+			values, err := parser.Floats(value)
+			if err != nil {
+				return nil, fmt.Errorf("entry #%d (%q): %w", j+1, strings.TrimSpace(entry), err)
+			}
+			floats = append(floats, values...)
+		}
+
+		return floats, nil
+	}
+
+	for i, line := range strings.Split(matrix, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		row, err := entries(line)
+		if err != nil {
+			return nil, nil, fmt.Errorf("row #%d: %w", i+1, err)
+		}
+		a = append(a, row)
+	}
+
+	if b, err = entries(vector); err != nil {
+		return nil, nil, fmt.Errorf("vector b: %w", err)
+	}
+
+	return a, b, nil
+}
+
+// NewMatrixPanel creates a new matrix panel evaluating the entries with the given options.
+func NewMatrixPanel(options ...parser.Option) *MatrixPanel {
+	panel := &MatrixPanel{
+		data:      widget.NewMultiLineEntry(),
+		vector:    widget.NewEntry(),
+		status:    widget.NewLabel(""),
+		summary:   make(map[string]*widget.Label),
+		evaluator: parser.NewParser(options...),
+	}
+
+	for _, label := range matrixLabels {
+		panel.summary[label] = widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	}
+
+	panel.store = widget.NewButton("Store", panel.Store)
+	panel.store.Disable()
+
+	panel.data.SetPlaceHolder("2; 1\n1; 3")
+	panel.data.OnChanged = func(string) { panel.Update() }
+	panel.vector.SetPlaceHolder("3; 5")
+	panel.vector.OnChanged = func(string) { panel.Update() }
+	panel.ExtendBaseWidget(panel)
+
+	return panel
+}
+
+// formatMatrix formats the rows of a matrix as lines of right-aligned columns enclosed in brackets, e.g.:
+//
+//	⎡ 1  2⎤
+//	⎣-3  4⎦
+//
+// A matrix with a single row is enclosed in square brackets.
+func formatMatrix(rows [][]*big.Float, format byte, prec int) []string {
+	texts, widths := make([][]string, len(rows)), make([]int, 0)
+	for i, row := range rows {
+		texts[i] = make([]string, len(row))
+		for j, entry := range row {
+			texts[i][j] = entry.Text(format, prec)
+			if j >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[j] = max(widths[j], utf8.RuneCountInString(texts[i][j]))
+		}
+	}
+
+	lines := make([]string, len(rows))
+	for i, row := range texts {
+		left, right := "⎢", "⎥"
+		switch {
+		case len(rows) == 1:
+			left, right = "[", "]"
+
+		case i == 0:
+			left, right = "⎡", "⎤"
+
+		case i == len(rows)-1:
+			left, right = "⎣", "⎦"
+
+		}
+
+		for j, text := range row {
+			row[j] = strings.Repeat(" ", widths[j]-utf8.RuneCountInString(text)) + text
+		}
+
+		lines[i] = left + strings.Join(row, "  ") + right
+	}
+
+	return lines
+}
+
+// matrixRows returns the rows of the value if it is a matrix, i.e., a list of lists of numbers.
+func matrixRows(value parser.Value) ([][]*big.Float, bool) {
+	list, ok := value.(parser.List)
+	if !ok || len(list) == 0 {
+		return nil, false
+	}
+
+	if _, ok := list[0].(parser.List); !ok {
+		return nil, false
+	}
+
+	rows, err := parser.Matrix(value)
+	return rows, err == nil
+}
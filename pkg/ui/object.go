@@ -30,6 +30,24 @@ func (o ObjectStorage) SelectIcons(in ...string) (out []*Icon) {
 	return selectObjects[*Icon](o, in...)
 }
 
+// SelectMatrixPanel selects the matrix panel from the object storage.
+func (o ObjectStorage) SelectMatrixPanel(in string) (out *MatrixPanel) {
+	v, _ := o[in].(*MatrixPanel)
+	return v
+}
+
+// SelectRegressionPanel selects the regression panel from the object storage.
+func (o ObjectStorage) SelectRegressionPanel(in string) (out *RegressionPanel) {
+	v, _ := o[in].(*RegressionPanel)
+	return v
+}
+
+// SelectStatisticsPanel selects the statistics panel from the object storage.
+func (o ObjectStorage) SelectStatisticsPanel(in string) (out *StatisticsPanel) {
+	v, _ := o[in].(*StatisticsPanel)
+	return v
+}
+
 // SelectCanvasObjects selects the canvas objects from the object storage.
 func (o ObjectStorage) SelectCanvasObjects(in ...string) (out []fyne.CanvasObject) {
 	return selectObjects[fyne.CanvasObject](o, in...)
@@ -0,0 +1,201 @@
+//go:build !headless
+
+package ui
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/sarumaj/edu-taschenrechner/pkg/calc"
+	"github.com/sarumaj/edu-taschenrechner/pkg/parser"
+)
+
+// regressionLabels defines the order and the labels of the results shown in the regression panel.
+var regressionLabels = []string{"equation", "coefficients", "r²", "residuals"}
+
+// RegressionPanel is a custom widget to enter data points and fit a regression model to them.
+// Each line of the data list holds one data point, whose x and y values are separated by a semicolon
+// and may be arbitrary expressions.
+// A fitted model can be stored as a prediction function fit1, fit2, ... to be called in expressions.
+type RegressionPanel struct {
+	widget.BaseWidget
+	data      *widget.Entry
+	degree    *widget.Entry
+	model     *widget.Select
+	store     *widget.Button
+	status    *widget.Label
+	summary   map[string]*widget.Label
+	fit       *calc.Regression
+	fits      int
+	onStored  func(name string, fit *calc.Regression)
+	evaluator parser.Parser
+}
+
+// CreateRenderer creates the renderer for the regression panel.
+func (p *RegressionPanel) CreateRenderer() fyne.WidgetRenderer {
+	form := widget.NewForm(widget.NewFormItem("model", p.model), widget.NewFormItem("degree", p.degree))
+	for _, label := range regressionLabels {
+		form.Append(label, p.summary[label])
+	}
+
+	return widget.NewSimpleRenderer(container.NewBorder(
+		widget.NewLabel("Enter one data point per line and separate x and y with a semicolon:"),
+		container.NewBorder(nil, nil, nil, p.store, p.status), nil, nil,
+		container.NewGridWithColumns(2, p.data, container.NewVScroll(form)),
+	))
+}
+
+// Fit fits the selected regression model to the data list and displays the results.
+func (p *RegressionPanel) Fit() {
+	p.fit = nil
+	p.store.Disable()
+	for _, label := range p.summary {
+		label.SetText("")
+	}
+
+	xs, ys, err := p.Values(p.data.Text)
+	if err == nil && len(xs) == 0 {
+		p.status.SetText("")
+		return
+	}
+
+	degree := 1
+	if err == nil && calc.Model(p.model.Selected) == calc.PolynomialModel {
+		degree, err = strconv.Atoi(strings.TrimSpace(p.degree.Text))
+	}
+
+	if err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		p.fit, err = calc.Fit(ctx, calc.Model(p.model.Selected), degree, xs, ys)
+	}
+
+	if err != nil {
+		p.status.SetText(err.Error())
+		return
+	}
+
+	coefficients := make([]string, len(p.fit.Coefficients))
+	for i, coefficient := range p.fit.Coefficients {
+		coefficients[i] = coefficient.Text('g', -1)
+	}
+
+	residuals := make([]string, len(p.fit.Residuals))
+	for i, residual := range p.fit.Residuals {
+		residuals[i] = residual.Text('g', 10)
+	}
+
+	p.summary["equation"].SetText(p.fit.String())
+	p.summary["coefficients"].SetText(strings.Join(coefficients, "\n"))
+	p.summary["r²"].SetText(p.fit.RSquared.Text('g', -1))
+	p.summary["residuals"].SetText(strings.Join(residuals, "\n"))
+	p.status.SetText(fmt.Sprintf("%d data points", len(xs)))
+	p.store.Enable()
+}
+
+// SetOnStored sets the function that is called when a fitted model is stored as a prediction function.
+func (p *RegressionPanel) SetOnStored(fn func(name string, fit *calc.Regression)) *RegressionPanel {
+	p.onStored = fn
+	return p
+}
+
+// SetText sets the data list of the regression panel.
+func (p *RegressionPanel) SetText(text string) { p.data.SetText(text) }
+
+// ShowDialog displays the regression panel in a dialog of the given window.
+func (p *RegressionPanel) ShowDialog(window fyne.Window) {
+	info := dialog.NewCustom("Regression", "Close", p, window)
+	info.Resize(fyne.NewSize(window.Canvas().Size().Width*0.9, window.Canvas().Size().Height*0.9))
+	info.Show()
+}
+
+// Store stores the fitted model as the next prediction function fit1, fit2, ...
+// It returns the name of the prediction function.
+func (p *RegressionPanel) Store() string {
+	if p.fit == nil {
+		return ""
+	}
+
+	p.fits++
+	name := fmt.Sprintf("fit%d", p.fits)
+	if p.onStored != nil {
+		p.onStored(name, p.fit)
+	}
+
+	p.status.SetText(fmt.Sprintf("stored as %s(x)", name))
+	return name
+}
+
+// Values evaluates the data points of the data list given as text.
+// Empty lines are skipped.
+func (p *RegressionPanel) Values(text string) (xs, ys []*big.Float, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	for i, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		pair := strings.Split(line, ";")
+		if len(pair) != 2 {
+			return nil, nil, fmt.Errorf("data point #%d (%q): expected x and y separated by a semicolon", i+1, strings.TrimSpace(line))
+		}
+
+		x, err := p.evaluator.Parse(ctx, pair[0])
+		if err != nil {
+			return nil, nil, fmt.Errorf("data point #%d (%q): %w", i+1, strings.TrimSpace(line), err)
+		}
+
+		y, err := p.evaluator.Parse(ctx, pair[1])
+		if err != nil {
+			return nil, nil, fmt.Errorf("data point #%d (%q): %w", i+1, strings.TrimSpace(line), err)
+		}
+
+		xs, ys = append(xs, x), append(ys, y)
+	}
+
+	return xs, ys, nil
+}
+
+// NewRegressionPanel creates a new regression panel evaluating the data points with the given options.
+func NewRegressionPanel(options ...parser.Option) *RegressionPanel {
+	models := make([]string, len(calc.Models))
+	for i, model := range calc.Models {
+		models[i] = string(model)
+	}
+
+	panel := &RegressionPanel{
+		data:      widget.NewMultiLineEntry(),
+		degree:    widget.NewEntry(),
+		status:    widget.NewLabel(""),
+		summary:   make(map[string]*widget.Label),
+		evaluator: parser.NewParser(options...),
+	}
+
+	for _, label := range regressionLabels {
+		panel.summary[label] = widget.NewLabel("")
+	}
+
+	panel.model = widget.NewSelect(models, func(string) { panel.Fit() })
+	panel.store = widget.NewButton("Store", func() { panel.Store() })
+	panel.store.Disable()
+
+	panel.degree.SetText("2")
+	panel.degree.OnChanged = func(string) { panel.Fit() }
+	panel.data.SetPlaceHolder("1; 2.2\n2; 2.8\n3; 3.6")
+	panel.data.OnChanged = func(string) { panel.Fit() }
+	panel.model.SetSelected(models[0])
+	panel.ExtendBaseWidget(panel)
+
+	return panel
+}
@@ -0,0 +1,201 @@
+//go:build !headless
+
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/sarumaj/edu-taschenrechner/pkg/calc"
+	"github.com/sarumaj/edu-taschenrechner/pkg/parser"
+)
+
+// statisticsDelay is the delay of the calculation after a change of the data list.
+// Further changes within the delay cancel the pending calculation, so that it is not repeated on every keystroke.
+const statisticsDelay = 300 * time.Millisecond
+
+// statisticsLabels defines the order and the labels of the summary values shown in the statistics panel.
+var statisticsLabels = []string{"n", "Σx", "mean", "median", "mode", "min", "max", "range", "Q1", "Q3", "σ²", "σ", "s²", "s"}
+
+// StatisticsPanel is a custom widget to enter a data list and see all its summary statistics at once.
+// Values of the data list are separated by new lines or semicolons and may be arbitrary expressions,
+// including lists, whose elements are added to the data list.
+// The summary statistics are calculated in the background, a change of the data list cancels the pending calculation.
+type StatisticsPanel struct {
+	widget.BaseWidget
+	data      *widget.Entry
+	status    *widget.Label
+	summary   map[string]*widget.Label
+	evaluator parser.Parser
+	mutex     sync.Mutex         // guards cancel and the labels against stale calculations
+	cancel    context.CancelFunc // cancels the pending calculation
+}
+
+// CreateRenderer creates the renderer for the statistics panel.
+func (p *StatisticsPanel) CreateRenderer() fyne.WidgetRenderer {
+	form := widget.NewForm()
+	for _, label := range statisticsLabels {
+		form.Append(label, p.summary[label])
+	}
+
+	return widget.NewSimpleRenderer(container.NewBorder(
+		widget.NewLabel("Enter one value per line or separate values with semicolons:"),
+		p.status, nil, nil,
+		container.NewGridWithColumns(2, p.data, container.NewVScroll(form)),
+	))
+}
+
+// GetOnChanged returns a function that updates the summary statistics upon changes of the data list.
+// The calculation runs in a separate goroutine after statisticsDelay and is canceled by further changes.
+func (p *StatisticsPanel) GetOnChanged() func(string) {
+	return func(text string) {
+		// cancel the pending calculation, its results are stale
+		p.mutex.Lock()
+		if p.cancel != nil {
+			p.cancel()
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		p.cancel = cancel
+		p.mutex.Unlock()
+
+		p.status.SetText("Calculating...")
+
+		// define a channel for synchronization
+		var done chan struct{}
+		if !Interactive {
+			done = make(chan struct{})
+		}
+
+		go func(done chan<- struct{}) {
+			defer cancel()
+
+			// wait for further changes of the data list
+			if Interactive {
+				select {
+				case <-ctx.Done():
+					return
+
+				case <-time.After(statisticsDelay):
+				}
+			}
+
+			values, err := p.Values(ctx, text)
+			var stats *calc.Statistics
+			if err == nil && len(values) > 0 {
+				stats, err = calc.Describe(ctx, values...)
+			}
+
+			p.show(ctx, len(values), stats, err)
+
+			if !Interactive {
+				done <- struct{}{} // Signal the end of the operation
+				close(done)
+			}
+		}(done)
+
+		if !Interactive {
+			<-done
+		}
+	}
+}
+
+// SetText sets the data list of the statistics panel.
+func (p *StatisticsPanel) SetText(text string) { p.data.SetText(text) }
+
+// ShowDialog displays the statistics panel in a dialog of the given window.
+func (p *StatisticsPanel) ShowDialog(window fyne.Window) {
+	info := dialog.NewCustom("Statistics", "Close", p, window)
+	info.Resize(fyne.NewSize(window.Canvas().Size().Width*0.9, window.Canvas().Size().Height*0.9))
+	info.Show()
+}
+
+// show displays the summary statistics of n values or the error of their calculation,
+// unless the calculation was canceled by a change of the data list.
+func (p *StatisticsPanel) show(ctx context.Context, n int, stats *calc.Statistics, err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return
+	}
+
+	for _, label := range p.summary {
+		label.SetText("")
+	}
+
+	if err != nil {
+		p.status.SetText(err.Error())
+		return
+	}
+
+	p.status.SetText(fmt.Sprintf("%d values", n))
+	if stats == nil {
+		return
+	}
+
+	for i, value := range []*big.Float{
+		stats.Count, stats.Sum, stats.Mean, stats.Median, stats.Mode,
+		stats.Minimum, stats.Maximum, stats.Range, stats.LowerQuartile, stats.UpperQuartile,
+		stats.PopulationVariance, stats.PopulationStandardDeviation, stats.SampleVariance, stats.SampleStandardDeviation,
+	} {
+		text := "undefined"
+		if value != nil {
+			text = value.Text('g', -1)
+		}
+		p.summary[statisticsLabels[i]].SetText(text)
+	}
+}
+
+// Values evaluates the values of the data list given as text.
+// Empty values are skipped.
+func (p *StatisticsPanel) Values(ctx context.Context, text string) ([]*big.Float, error) {
+	var values []*big.Float
+	for i, line := range strings.FieldsFunc(text, func(r rune) bool { return r == '\n' || r == ';' }) {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		value, err := p.evaluator.ParseValue(ctx, line)
+		if err != nil {
+			return nil, fmt.Errorf("value #%d (%q): %w", i+1, strings.TrimSpace(line), err)
+		}
+
+		// lists contribute all their elements to the data list
+		floats, err := parser.Floats(value)
+		if err != nil {
+			return nil, fmt.Errorf("value #%d (%q): %w", i+1, strings.TrimSpace(line), err)
+		}
+		values = append(values, floats...)
+	}
+
+	return values, nil
+}
+
+// NewStatisticsPanel creates a new statistics panel evaluating the values of the data list with the given options.
+func NewStatisticsPanel(options ...parser.Option) *StatisticsPanel {
+	panel := &StatisticsPanel{
+		data:      widget.NewMultiLineEntry(),
+		status:    widget.NewLabel(""),
+		summary:   make(map[string]*widget.Label),
+		evaluator: parser.NewParser(options...),
+	}
+
+	for _, label := range statisticsLabels {
+		panel.summary[label] = widget.NewLabel("")
+	}
+
+	panel.data.SetPlaceHolder("1.5\n2\n3/4")
+	panel.data.OnChanged = panel.GetOnChanged()
+	panel.ExtendBaseWidget(panel)
+
+	return panel
+}
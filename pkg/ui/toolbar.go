@@ -59,11 +59,21 @@ func NewDisplayToolbar(display fyne.CanvasObject, actions ...widget.ToolbarItem)
 		if display.Entry.ActionItem == nil {
 			actions = append([]widget.ToolbarItem{
 				NewToolbarItem(theme.ContentCopyIcon()).SetOnTapped(display.CopyToClipboard),
+				NewToolbarItem(theme.DocumentIcon()).SetOnTapped(display.ShowNotations),
+				NewToolbarItem(theme.InfoIcon()).SetOnTapped(display.ShowWork),
+				NewToolbarItem(theme.ZoomInIcon()).SetOnTapped(display.ShowDigits),
+				NewToolbarItem(theme.HelpIcon()).SetOnTapped(display.ShowHelp),
+				NewIntervalsToolbarItem(display),
 				NewToolbarItem(theme.SettingsIcon()).SetOnTapped(display.MeasureDisplayCapacity),
 			}, actions...)
 		} else {
 			actions = append([]widget.ToolbarItem{
 				NewToolbarItem(theme.ContentCopyIcon()).SetOnTapped(display.CopyToClipboard),
+				NewToolbarItem(theme.DocumentIcon()).SetOnTapped(display.ShowNotations),
+				NewToolbarItem(theme.InfoIcon()).SetOnTapped(display.ShowWork),
+				NewToolbarItem(theme.ZoomInIcon()).SetOnTapped(display.ShowDigits),
+				NewToolbarItem(theme.HelpIcon()).SetOnTapped(display.ShowHelp),
+				NewIntervalsToolbarItem(display),
 			}, actions...)
 		}
 	}
@@ -71,6 +81,19 @@ func NewDisplayToolbar(display fyne.CanvasObject, actions ...widget.ToolbarItem)
 	return NewToolbar(actions...)
 }
 
+// NewIntervalsToolbarItem creates a toolbar item toggling interval arithmetic of the display, see Display.ToggleIntervals.
+// Its icon shows whether interval arithmetic is enabled.
+func NewIntervalsToolbarItem(display *Display) *ToolbarItem {
+	item := NewToolbarItem(theme.RadioButtonIcon())
+	return item.SetOnTapped(func() {
+		if display.ToggleIntervals() {
+			item.SetIcon(theme.RadioButtonCheckedIcon())
+		} else {
+			item.SetIcon(theme.RadioButtonIcon())
+		}
+	})
+}
+
 // NewToolbar creates a new toolbar with the given actions.
 func NewToolbar(actions ...widget.ToolbarItem) *Toolbar {
 	t := &Toolbar{}